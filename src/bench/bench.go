@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+// Stage names, in the order they are measured.
+const (
+	Parse = "parse"
+	Part1 = "part1"
+	Part2 = "part2"
+)
+
+// Stage holds the averaged cost of one stage of a solver.
+type Stage struct {
+	Name           string        `json:"name"`
+	Runs           int           `json:"runs"`
+	TimePerRun     time.Duration `json:"ns_per_run"`
+	AllocsPerRun   uint64        `json:"allocs_per_run"`
+	BytesPerRun    uint64        `json:"bytes_per_run"`
+	NotImplemented bool          `json:"not_implemented,omitempty"`
+}
+
+// Result holds the stages measured for one day.
+type Result struct {
+	Day    int     `json:"day"`
+	Input  string  `json:"input"`
+	Stages []Stage `json:"stages"`
+}
+
+type totals struct {
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+}
+
+// Measure parses lines and solves both parts of a day runs times, each run
+// with a new solver, and averages the time and allocations of every stage.
+func Measure(day int, inputName string, lines []string, runs int) (Result, error) {
+	if runs < 1 {
+		return Result{}, fmt.Errorf("invalid number of runs %d", runs)
+	}
+
+	stageTotals := make([]totals, 3)
+	notImplemented := make([]bool, 3)
+
+	for run := 0; run < runs; run = run + 1 {
+		s, err := solver.New(day)
+
+		if err != nil {
+			return Result{}, err
+		}
+
+		err = measure(&stageTotals[0], func() error { return s.Parse(lines) })
+
+		if err != nil {
+			return Result{}, fmt.Errorf("day %d parse: %w", day, err)
+		}
+
+		for part := 1; part <= 2; part = part + 1 {
+			err = measure(&stageTotals[part], func() error {
+				_, err := solver.Solve(s, part)
+				return err
+			})
+
+			if errors.Is(err, solver.ErrNotImplemented) {
+				notImplemented[part] = true
+				continue
+			}
+
+			if err != nil {
+				return Result{}, fmt.Errorf("day %d part %d: %w", day, part, err)
+			}
+		}
+	}
+
+	result := Result{Day: day, Input: inputName}
+
+	for index, name := range []string{Parse, Part1, Part2} {
+		stage := Stage{Name: name, Runs: runs, NotImplemented: notImplemented[index]}
+
+		if !stage.NotImplemented {
+			stage.TimePerRun = stageTotals[index].elapsed / time.Duration(runs)
+			stage.AllocsPerRun = stageTotals[index].allocs / uint64(runs)
+			stage.BytesPerRun = stageTotals[index].bytes / uint64(runs)
+		}
+
+		result.Stages = append(result.Stages, stage)
+	}
+
+	return result, nil
+}
+
+func measure(total *totals, f func() error) error {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	err := f()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	total.elapsed = total.elapsed + elapsed
+	total.allocs = total.allocs + (after.Mallocs - before.Mallocs)
+	total.bytes = total.bytes + (after.TotalAlloc - before.TotalAlloc)
+
+	return err
+}
+
+// WriteTable writes the results as an aligned text table.
+func WriteTable(w io.Writer, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(table, "day\tstage\truns\ttime/run\tallocs/run\tbytes/run\t")
+
+	for _, result := range results {
+		for _, stage := range result.Stages {
+			if stage.NotImplemented {
+				fmt.Fprintf(table, "%d\t%s\t%d\t-\t-\t-\t\n", result.Day, stage.Name, stage.Runs)
+				continue
+			}
+
+			fmt.Fprintf(table, "%d\t%s\t%d\t%s\t%d\t%d\t\n",
+				result.Day, stage.Name, stage.Runs, stage.TimePerRun, stage.AllocsPerRun, stage.BytesPerRun)
+		}
+	}
+
+	return table.Flush()
+}
+
+// WriteJSON writes the results as an indented JSON array.
+func WriteJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
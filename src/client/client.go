@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	Year           = 2023
+
+	// SessionEnv names the environment variable holding the session cookie.
+	SessionEnv = "AOC_SESSION"
+
+	// DefaultMinInterval is the shortest time allowed between two requests.
+	DefaultMinInterval = 3 * time.Second
+
+	// LastRequestFileName is the file of the inputs directory recording when
+	// the last request was sent.
+	LastRequestFileName = ".last-request"
+
+	userAgent = "github.com/gabrielgry/advent-of-code-2023"
+)
+
+// ErrNoSession is returned when a request needs a session token and the
+// client has none.
+var ErrNoSession = errors.New("missing session token, set $" + SessionEnv)
+
+// Client talks to the Advent of Code website on behalf of the user whose
+// session token it holds. It never sends two requests closer than
+// MinInterval.
+type Client struct {
+	BaseURL     string
+	Session     string
+	HTTPClient  *http.Client
+	MinInterval time.Duration
+	// LastRequestFile records when the last request was sent, so the
+	// interval also holds between clients of different processes sharing
+	// it. An empty path keeps the time in memory only.
+	LastRequestFile string
+
+	mutex       sync.Mutex
+	lastRequest time.Time
+}
+
+// New returns a Client for the website using httpClient, or
+// http.DefaultClient when httpClient is nil.
+func New(session string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		BaseURL:     DefaultBaseURL,
+		Session:     session,
+		HTTPClient:  httpClient,
+		MinInterval: DefaultMinInterval,
+	}
+}
+
+// LastRequestFile returns the file recording the last request in the
+// directory of resolver.
+func LastRequestFile(resolver input.Resolver) string {
+	return filepath.Join(resolver.Dir, LastRequestFileName)
+}
+
+func (c *Client) dayURL(day int, suffix string) string {
+	return fmt.Sprintf("%s/%d/day/%d%s", strings.TrimSuffix(c.BaseURL, "/"), Year, day, suffix)
+}
+
+func (c *Client) wait(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	lastRequest := c.lastRequest
+
+	if recorded, ok := c.readLastRequest(); ok && recorded.After(lastRequest) {
+		lastRequest = recorded
+	}
+
+	if !lastRequest.IsZero() {
+		delay := c.MinInterval - time.Since(lastRequest)
+
+		// A time recorded by a machine whose clock runs ahead never
+		// delays a request for longer than the interval.
+		if delay > c.MinInterval {
+			delay = c.MinInterval
+		}
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	c.lastRequest = time.Now()
+
+	return c.recordLastRequest()
+}
+
+// readLastRequest returns the time recorded in LastRequestFile. A missing or
+// unreadable record is no record at all.
+func (c *Client) readLastRequest() (time.Time, bool) {
+	if c.LastRequestFile == "" {
+		return time.Time{}, false
+	}
+
+	data, err := os.ReadFile(c.LastRequestFile)
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	recorded, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return recorded, true
+}
+
+func (c *Client) recordLastRequest() error {
+	if c.LastRequestFile == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.LastRequestFile), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.LastRequestFile, []byte(c.lastRequest.Format(time.RFC3339Nano)+"\n"), 0o644)
+}
+
+func (c *Client) do(ctx context.Context, request *http.Request) ([]byte, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	request.Header.Set("User-Agent", userAgent)
+
+	response, err := c.HTTPClient.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", request.Method, request.URL, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+// Input downloads the puzzle input of a day.
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.dayURL(day, "/input"), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, request)
+}
+
+// FetchInput stores the input of a day as the named input of resolver,
+// downloading it only when it is not stored yet. It returns the path of the
+// stored input and whether it was downloaded.
+func (c *Client) FetchInput(ctx context.Context, resolver input.Resolver, day int, name string) (string, bool, error) {
+	path := resolver.Path(day, name)
+
+	if _, err := os.Stat(path); err == nil {
+		return path, false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", false, err
+	}
+
+	data, err := c.Input(ctx, day)
+
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return "", false, err
+	}
+
+	return path, true, nil
+}
+
+// writeFileAtomic writes through a temporary file so an interrupted download
+// never leaves a partial input behind to be mistaken for a cached one.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
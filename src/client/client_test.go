@@ -0,0 +1,146 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+const puzzleInput = "0 3 6 9 12 15\n"
+
+func newInputServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Path != "/2023/day/9/input" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(puzzleInput))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newClient(server *httptest.Server, session string) *client.Client {
+	c := client.New(session, server.Client())
+	c.BaseURL = server.URL
+	c.MinInterval = 0
+
+	return c
+}
+
+func TestFetchInputCaches(t *testing.T) {
+	var requests int32
+	server := newInputServer(t, &requests)
+	c := newClient(server, "secret")
+	resolver := input.NewResolver(t.TempDir())
+
+	path, downloaded, err := c.FetchInput(context.Background(), resolver, 9, "input")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !downloaded {
+		t.Error("first fetch was not downloaded")
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != puzzleInput {
+		t.Errorf("stored input = %q, want %q", data, puzzleInput)
+	}
+
+	if _, downloaded, err := c.FetchInput(context.Background(), resolver, 9, "input"); err != nil || downloaded {
+		t.Errorf("second fetch downloaded = %v, err = %v, want cached", downloaded, err)
+	}
+
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	var requests int32
+	server := newInputServer(t, &requests)
+	resolver := input.NewResolver(t.TempDir())
+
+	if _, _, err := newClient(server, "").FetchInput(context.Background(), resolver, 9, "input"); err != client.ErrNoSession {
+		t.Errorf("fetch without session: err = %v, want ErrNoSession", err)
+	}
+
+	if _, _, err := newClient(server, "expired").FetchInput(context.Background(), resolver, 9, "input"); err == nil {
+		t.Error("fetch with a rejected session succeeded")
+	}
+
+	if _, _, err := newClient(server, "secret").FetchInput(context.Background(), resolver, 26, "input"); err == nil {
+		t.Error("fetch of a missing day succeeded")
+	}
+
+	if _, err := os.Stat(resolver.Path(9, "input")); !os.IsNotExist(err) {
+		t.Errorf("failed fetch left an input behind: %v", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var requests int32
+	server := newInputServer(t, &requests)
+	c := newClient(server, "secret")
+	c.MinInterval = 50 * time.Millisecond
+
+	start := time.Now()
+
+	for i := 0; i < 3; i = i + 1 {
+		if _, err := c.Input(context.Background(), 9); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*c.MinInterval {
+		t.Errorf("3 requests took %s, want at least %s", elapsed, 2*c.MinInterval)
+	}
+}
+
+func TestRateLimitAcrossClients(t *testing.T) {
+	var requests int32
+	server := newInputServer(t, &requests)
+	resolver := input.NewResolver(t.TempDir())
+
+	start := time.Now()
+
+	for i := 0; i < 3; i = i + 1 {
+		c := newClient(server, "secret")
+		c.MinInterval = 50 * time.Millisecond
+		c.LastRequestFile = client.LastRequestFile(resolver)
+
+		if _, err := c.Input(context.Background(), 9); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests from new clients took %s, want at least 100ms", elapsed)
+	}
+}
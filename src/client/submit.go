@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Verdict is the website's judgement of a submitted answer.
+type Verdict int
+
+const (
+	Unknown Verdict = iota
+	Correct
+	Incorrect
+	TooHigh
+	TooLow
+	TooRecent
+	WrongLevel
+)
+
+var verdictNames = map[Verdict]string{
+	Unknown:    "unknown",
+	Correct:    "correct",
+	Incorrect:  "incorrect",
+	TooHigh:    "too-high",
+	TooLow:     "too-low",
+	TooRecent:  "too-recent",
+	WrongLevel: "wrong-level",
+}
+
+func (v Verdict) String() string {
+	if name, ok := verdictNames[v]; ok {
+		return name
+	}
+
+	return "Verdict(" + strconv.Itoa(int(v)) + ")"
+}
+
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for verdict, name := range verdictNames {
+		if name == string(text) {
+			*v = verdict
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// Wrong reports whether the verdict rejects the answer itself, as opposed
+// to the submission being refused or not understood.
+func (v Verdict) Wrong() bool {
+	return v == Incorrect || v == TooHigh || v == TooLow
+}
+
+// Submission is the outcome of submitting an answer.
+type Submission struct {
+	Verdict Verdict
+	// Message is the text of the website's response, without markup.
+	Message string
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+)
+
+// ParseSubmission reads the verdict out of the page the website answers a
+// submission with.
+func ParseSubmission(page string) Submission {
+	message := page
+
+	if match := articlePattern.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.TrimSpace(spacePattern.ReplaceAllString(message, " "))
+
+	submission := Submission{Message: message}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		submission.Verdict = Correct
+	case strings.Contains(message, "your answer is too high"):
+		submission.Verdict = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		submission.Verdict = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		submission.Verdict = Incorrect
+	case strings.Contains(message, "You gave an answer too recently"):
+		submission.Verdict = TooRecent
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		submission.Verdict = WrongLevel
+	}
+
+	return submission
+}
+
+// Submit posts the answer to one part of a day's puzzle.
+func (c *Client) Submit(ctx context.Context, day int, part int, answer string) (Submission, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.dayURL(day, "/answer"), strings.NewReader(form.Encode()))
+
+	if err != nil {
+		return Submission{}, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	page, err := c.do(ctx, request)
+
+	if err != nil {
+		return Submission{}, err
+	}
+
+	return ParseSubmission(string(page)), nil
+}
@@ -0,0 +1,81 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+)
+
+func page(message string) string {
+	return "<html><body><main>\n<article><p>" + message + "</p></article>\n</main></body></html>"
+}
+
+func TestParseSubmission(t *testing.T) {
+	tests := []struct {
+		page    string
+		verdict client.Verdict
+	}{
+		{page("That's the right answer!  You are <span class=\"day-success\">one gold star</span> closer."), client.Correct},
+		{page("That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data."), client.TooHigh},
+		{page("That's not the right answer; your answer is too low."), client.TooLow},
+		{page("That's not the right answer.  If you're stuck, there are some general tips on the <a href=\"/2023/about\">about page</a>."), client.Incorrect},
+		{page("You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 34s left to wait."), client.TooRecent},
+		{page("You don't seem to be solving the right level.  Did you already complete it?"), client.WrongLevel},
+		{"<html></html>", client.Unknown},
+	}
+
+	for _, test := range tests {
+		if submission := client.ParseSubmission(test.page); submission.Verdict != test.verdict {
+			t.Errorf("ParseSubmission(%q).Verdict = %s, want %s", test.page, submission.Verdict, test.verdict)
+		}
+	}
+
+	submission := client.ParseSubmission(page("That's not the right answer; your answer is too low.  Please wait one minute &amp; retry."))
+
+	if want := "That's not the right answer; your answer is too low. Please wait one minute & retry."; submission.Message != want {
+		t.Errorf("Message = %q, want %q", submission.Message, want)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/7/answer" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.FormValue("level") == "2" && r.FormValue("answer") == "5905" {
+			w.Write([]byte(page("That's the right answer!")))
+			return
+		}
+
+		w.Write([]byte(page("That's not the right answer; your answer is too low.")))
+	}))
+
+	defer server.Close()
+
+	c := newClient(server, "secret")
+
+	submission, err := c.Submit(context.Background(), 7, 2, "5905")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if submission.Verdict != client.Correct {
+		t.Errorf("Submit(7, 2, 5905) = %s, want %s", submission.Verdict, client.Correct)
+	}
+
+	submission, err = c.Submit(context.Background(), 7, 1, "5905")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if submission.Verdict != client.TooLow {
+		t.Errorf("Submit(7, 1, 5905) = %s, want %s", submission.Verdict, client.TooLow)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/bench"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := flags.Int("day", 0, "day to measure, 0 measures every day with an input")
+	inputs := addInputFlags(flags)
+	runs := flags.Int("runs", 5, "number of runs averaged for every stage")
+	asJSON := flags.Bool("json", false, "write the report as JSON")
+	flags.Parse(args)
+
+	if inputs.explicit() && *day == 0 {
+		return fmt.Errorf("-input requires -day")
+	}
+
+	days := solver.Days()
+
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	var results []bench.Result
+
+	for _, benchDay := range days {
+		lines, path, err := inputs.read(benchDay)
+
+		if *day == 0 && errors.Is(err, fs.ErrNotExist) {
+			log.Printf("skipping day %d: %s not found", benchDay, path)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		result, err := bench.Measure(benchDay, path, lines, *runs)
+
+		if err != nil {
+			return err
+		}
+
+		results = append(results, result)
+	}
+
+	if *asJSON {
+		return bench.WriteJSON(os.Stdout, results)
+	}
+
+	return bench.WriteTable(os.Stdout, results)
+}
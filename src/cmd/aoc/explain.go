@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+type partTrace struct {
+	Part  int          `json:"part"`
+	Trace solver.Trace `json:"trace"`
+}
+
+func explainCommand(args []string) error {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	day := flags.Int("day", 0, "day of the puzzle to explain (1-25)")
+	part := flags.Int("part", 0, "part of the puzzle to explain, 0 explains every part")
+	asJSON := flags.Bool("json", false, "write the traces as JSON")
+	inputs := addInputFlags(flags)
+	flags.Parse(args)
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %d", *part)
+	}
+
+	lines, _, err := inputs.read(*day)
+
+	if err != nil {
+		return err
+	}
+
+	s, err := solver.New(*day)
+
+	if err != nil {
+		return err
+	}
+
+	explainer, ok := s.(solver.Explainer)
+
+	if !ok {
+		return fmt.Errorf("day %d cannot be explained", *day)
+	}
+
+	if err := s.Parse(lines); err != nil {
+		return diagnose(err, lines)
+	}
+
+	var traces []partTrace
+
+	for partNumber := 1; partNumber <= 2; partNumber = partNumber + 1 {
+		if *part != 0 && *part != partNumber {
+			continue
+		}
+
+		trace, err := explainer.Explain(partNumber)
+
+		if *part == 0 && errors.Is(err, solver.ErrNotImplemented) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("day %d part %d: %w", *day, partNumber, err)
+		}
+
+		traces = append(traces, partTrace{Part: partNumber, Trace: trace})
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(traces)
+	}
+
+	for i, trace := range traces {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("Part %d:\n", trace.Part)
+
+		if err := trace.Trace.WriteText(os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
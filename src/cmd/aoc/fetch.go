@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := flags.Int("day", 0, "day whose input is fetched, 0 fetches every solved day")
+	dir := flags.String("inputs", "", "inputs directory (default $"+input.DirEnv+" or "+input.DefaultDir+")")
+	name := flags.String("name", "", "name the input is stored as (default $"+input.NameEnv+" or "+input.DefaultName+")")
+	session := flags.String("session", "", "session cookie (default $"+client.SessionEnv+")")
+	flags.Parse(args)
+
+	if *session == "" {
+		*session = os.Getenv(client.SessionEnv)
+	}
+
+	days := solver.Days()
+
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	resolver := input.NewResolver(*dir)
+	c := client.New(*session, nil)
+	c.LastRequestFile = client.LastRequestFile(resolver)
+	inputName := input.ResolveName(*name)
+
+	for _, fetchDay := range days {
+		path, downloaded, err := c.FetchInput(context.Background(), resolver, fetchDay, inputName)
+
+		if err != nil {
+			return fmt.Errorf("day %d: %w", fetchDay, err)
+		}
+
+		if downloaded {
+			fmt.Println("downloaded", path)
+		} else {
+			fmt.Println("cached", path)
+		}
+	}
+
+	return nil
+}
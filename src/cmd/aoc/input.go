@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+const stdinPath = "-"
+
+type inputFlags struct {
+	dir     *string
+	name    *string
+	path    *string
+	maxLine *int
+}
+
+func addInputFlags(flags *flag.FlagSet) *inputFlags {
+	return &inputFlags{
+		dir:     flags.String("inputs", "", "inputs directory (default $"+input.DirEnv+" or "+input.DefaultDir+")"),
+		name:    flags.String("name", "", "named input read from <inputs>/dayNN/<name>.txt (default $"+input.NameEnv+" or "+input.DefaultName+")"),
+		path:    flags.String("input", "", "input file overriding -inputs and -name, - reads stdin"),
+		maxLine: flags.Int("max-line", input.DefaultMaxLineLength, "longest input line accepted, in bytes"),
+	}
+}
+
+func (f *inputFlags) stdin() bool {
+	return *f.path == stdinPath
+}
+
+func (f *inputFlags) explicit() bool {
+	return *f.path != ""
+}
+
+func (f *inputFlags) resolver() input.Resolver {
+	return input.NewResolver(*f.dir)
+}
+
+// inputName returns the name of the selected input, or false when the input
+// was given as a file or stdin and has no name.
+func (f *inputFlags) inputName() (string, bool) {
+	if f.explicit() {
+		return "", false
+	}
+
+	return input.ResolveName(*f.name), true
+}
+
+func (f *inputFlags) inputPath(day int) string {
+	if f.explicit() {
+		return *f.path
+	}
+
+	return f.resolver().Path(day, input.ResolveName(*f.name))
+}
+
+// open opens the selected input of a day and returns where it is read from.
+func (f *inputFlags) open(day int) (io.ReadCloser, string, error) {
+	if f.stdin() {
+		return io.NopCloser(os.Stdin), "stdin", nil
+	}
+
+	path := f.inputPath(day)
+	file, err := os.Open(path)
+
+	return file, path, err
+}
+
+// read returns the lines of the selected input of a day and where they were
+// read from.
+func (f *inputFlags) read(day int) ([]string, string, error) {
+	reader, path, err := f.open(day)
+
+	if err != nil {
+		return nil, path, err
+	}
+
+	defer reader.Close()
+
+	lines, err := input.ReadLinesLimit(reader, *f.maxLine)
+
+	return lines, path, err
+}
+
+func inputsCommand(args []string) error {
+	flags := flag.NewFlagSet("inputs", flag.ExitOnError)
+	day := flags.Int("day", 0, "day whose inputs are listed, 0 lists every day")
+	dir := flags.String("inputs", "", "inputs directory (default $"+input.DirEnv+" or "+input.DefaultDir+")")
+	flags.Parse(args)
+
+	resolver := input.NewResolver(*dir)
+
+	days := []int{*day}
+
+	if *day == 0 {
+		days = nil
+
+		for listDay := 1; listDay <= 25; listDay = listDay + 1 {
+			days = append(days, listDay)
+		}
+	}
+
+	for _, listDay := range days {
+		names, err := resolver.Names(listDay)
+
+		if *day == 0 && os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			fmt.Printf("%d\t%s\t%s\n", listDay, name, resolver.Path(listDay, name))
+		}
+	}
+
+	return nil
+}
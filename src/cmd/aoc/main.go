@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run     solve a day's puzzle
+  explain trace how a day reaches its answers, line by line
+  query   answer a question about a day's parsed input
+  render  draw a day's parsed input with what it found highlighted
+  bench   measure the time and allocations of every day
+  inputs  list the named inputs of every day
+  fetch   download puzzle inputs that are not stored yet
+  submit  submit an answer and record it in the answers ledger
+
+Run "aoc <command> -h" for the flags of a command.
+`
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("aoc: ")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "explain":
+		err = explainCommand(os.Args[2:])
+	case "query":
+		err = queryCommand(os.Args[2:])
+	case "render":
+		err = renderCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "inputs":
+		err = inputsCommand(os.Args[2:])
+	case "fetch":
+		err = fetchCommand(os.Args[2:])
+	case "submit":
+		err = submitCommand(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(os.Stdout, usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+func queryCommand(args []string) error {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	day := flags.Int("day", 0, "day of the puzzle to query (1-25)")
+	inputs := addInputFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: aoc query -day N [flags] <query>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	query := strings.Join(flags.Args(), " ")
+
+	if query == "" {
+		return fmt.Errorf("missing query")
+	}
+
+	s, err := solver.New(*day)
+
+	if err != nil {
+		return err
+	}
+
+	querier, ok := s.(solver.Querier)
+
+	if !ok {
+		return fmt.Errorf("day %d cannot be queried", *day)
+	}
+
+	lines, _, err := inputs.read(*day)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.Parse(lines); err != nil {
+		return diagnose(err, lines)
+	}
+
+	answer, err := querier.Query(query)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(answer)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+func renderCommand(args []string) error {
+	flags := flag.NewFlagSet("render", flag.ExitOnError)
+	day := flags.Int("day", 0, "day of the puzzle to render (1-25)")
+	format := flags.String("format", "ansi", "output format, ansi for a terminal or html")
+	inputs := addInputFlags(flags)
+	flags.Parse(args)
+
+	s, err := solver.New(*day)
+
+	if err != nil {
+		return err
+	}
+
+	renderer, ok := s.(solver.Renderer)
+
+	if !ok {
+		return fmt.Errorf("day %d cannot be rendered", *day)
+	}
+
+	lines, _, err := inputs.read(*day)
+
+	if err != nil {
+		return err
+	}
+
+	if err := s.Parse(lines); err != nil {
+		return diagnose(err, lines)
+	}
+
+	return renderer.Render(os.Stdout, *format)
+}
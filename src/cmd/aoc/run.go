@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/ledger"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+type partAnswer struct {
+	Part   int
+	Answer solver.Answer
+}
+
+// solveDay parses lines and solves one part of a day, or every implemented
+// part when part is 0.
+func solveDay(day int, part int, lines []string) ([]partAnswer, error) {
+	if part < 0 || part > 2 {
+		return nil, fmt.Errorf("invalid part %d", part)
+	}
+
+	s, err := solver.New(day)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Parse(lines); err != nil {
+		return nil, diagnose(err, lines)
+	}
+
+	var answers []partAnswer
+
+	for partNumber := 1; partNumber <= 2; partNumber = partNumber + 1 {
+		if part != 0 && part != partNumber {
+			continue
+		}
+
+		answer, err := solver.Solve(s, partNumber)
+
+		if part == 0 && errors.Is(err, solver.ErrNotImplemented) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("day %d part %d: %w", day, partNumber, err)
+		}
+
+		answers = append(answers, partAnswer{Part: partNumber, Answer: answer})
+	}
+
+	return answers, nil
+}
+
+// streamDay solves one part of a day, or every part that can be streamed
+// when part is 0, reading the input once per part.
+func streamDay(day int, part int, inputs *inputFlags) ([]partAnswer, error) {
+	if part < 0 || part > 2 {
+		return nil, fmt.Errorf("invalid part %d", part)
+	}
+
+	if part == 0 && inputs.stdin() {
+		return nil, fmt.Errorf("streaming stdin needs -part, it can only be read once")
+	}
+
+	s, err := solver.New(day)
+
+	if err != nil {
+		return nil, err
+	}
+
+	streamer, ok := s.(solver.Streamer)
+
+	if !ok {
+		return nil, fmt.Errorf("day %d cannot be streamed", day)
+	}
+
+	var answers []partAnswer
+
+	for partNumber := 1; partNumber <= 2; partNumber = partNumber + 1 {
+		if part != 0 && part != partNumber {
+			continue
+		}
+
+		reader, _, err := inputs.open(day)
+
+		if err != nil {
+			return nil, err
+		}
+
+		answer, err := streamer.Stream(partNumber, reader, *inputs.maxLine)
+		reader.Close()
+
+		if part == 0 && errors.Is(err, solver.ErrNotImplemented) {
+			continue
+		}
+
+		var parseError *input.ParseError
+
+		if errors.As(err, &parseError) {
+			return nil, err
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("day %d part %d: %w", day, partNumber, err)
+		}
+
+		answers = append(answers, partAnswer{Part: partNumber, Answer: answer})
+	}
+
+	return answers, nil
+}
+
+// diagnose appends the line a parse error was found in to its message, with
+// a caret under the offending column.
+func diagnose(err error, lines []string) error {
+	var parseError *input.ParseError
+
+	if !errors.As(err, &parseError) || parseError.Line < 1 || parseError.Line > len(lines) {
+		return err
+	}
+
+	line := lines[parseError.Line-1]
+	diagnostic := fmt.Errorf("%w\n\t%s", err, line)
+
+	if parseError.Column < 1 || parseError.Column > len(line)+1 {
+		return diagnostic
+	}
+
+	caret := strings.Map(func(char rune) rune {
+		if char == '\t' {
+			return char
+		}
+
+		return ' '
+	}, line[:parseError.Column-1])
+
+	return fmt.Errorf("%w\n\t%s^", diagnostic, caret)
+}
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	day := flags.Int("day", 0, "day of the puzzle to solve (1-25)")
+	part := flags.Int("part", 0, "part of the puzzle to solve, 0 solves every part")
+	stream := flags.Bool("stream", false, "solve line by line without loading the whole input, for the parts that allow it")
+	inputs := addInputFlags(flags)
+	flags.Parse(args)
+
+	var answers []partAnswer
+	var err error
+
+	if *stream {
+		answers, err = streamDay(*day, *part, inputs)
+	} else {
+		var lines []string
+
+		lines, _, err = inputs.read(*day)
+
+		if err == nil {
+			answers, err = solveDay(*day, *part, lines)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	diverged := 0
+
+	for _, answer := range answers {
+		accepted, err := acceptedAnswer(inputs, *day, answer.Part)
+
+		if err != nil {
+			return err
+		}
+
+		if accepted != "" && accepted != answer.Answer.String() {
+			fmt.Printf("Part %d: %s (differs from accepted answer %s)\n", answer.Part, answer.Answer, accepted)
+			diverged = diverged + 1
+			continue
+		}
+
+		fmt.Printf("Part %d: %s\n", answer.Part, answer.Answer)
+	}
+
+	if diverged > 0 {
+		return fmt.Errorf("%d answers differ from the ones accepted", diverged)
+	}
+
+	return nil
+}
+
+// acceptedAnswer returns the answer the ledger holds as accepted for the
+// selected input, or an empty string when there is none.
+func acceptedAnswer(inputs *inputFlags, day int, part int) (string, error) {
+	name, ok := inputs.inputName()
+
+	if !ok {
+		return "", nil
+	}
+
+	history, err := ledger.ForInputs(inputs.resolver()).History(day, part, name)
+
+	if err != nil {
+		return "", err
+	}
+
+	accepted, _ := history.Accepted()
+
+	return accepted, nil
+}
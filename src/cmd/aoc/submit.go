@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+	"github.com/gabrielgry/advent-of-code-2023/src/ledger"
+)
+
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := flags.Int("day", 0, "day of the puzzle (1-25)")
+	part := flags.Int("part", 0, "part of the puzzle (1 or 2)")
+	answer := flags.String("answer", "", "answer to submit (default solves the selected input)")
+	session := flags.String("session", "", "session cookie (default $"+client.SessionEnv+")")
+	inputs := addInputFlags(flags)
+	flags.Parse(args)
+
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("-part must be 1 or 2")
+	}
+
+	inputName, ok := inputs.inputName()
+
+	if !ok {
+		return fmt.Errorf("answers are recorded per named input, use -name instead of -input")
+	}
+
+	if *answer == "" {
+		lines, _, err := inputs.read(*day)
+
+		if err != nil {
+			return err
+		}
+
+		answers, err := solveDay(*day, *part, lines)
+
+		if err != nil {
+			return err
+		}
+
+		*answer = answers[0].Answer.String()
+	}
+
+	answers := ledger.ForInputs(inputs.resolver())
+
+	history, err := answers.History(*day, *part, inputName)
+
+	if err != nil {
+		return err
+	}
+
+	if accepted, ok := history.Accepted(); ok {
+		fmt.Printf("Day %d part %d was already solved with %s\n", *day, *part, accepted)
+		return nil
+	}
+
+	if reason := history.Check(*answer); reason != "" {
+		return fmt.Errorf("not submitting: %s", reason)
+	}
+
+	if *session == "" {
+		*session = os.Getenv(client.SessionEnv)
+	}
+
+	c := client.New(*session, nil)
+	c.LastRequestFile = client.LastRequestFile(inputs.resolver())
+
+	submission, err := c.Submit(context.Background(), *day, *part, *answer)
+
+	if err != nil {
+		return err
+	}
+
+	attempt := ledger.Attempt{
+		Time:    time.Now().UTC(),
+		Day:     *day,
+		Part:    *part,
+		Input:   inputName,
+		Answer:  *answer,
+		Verdict: submission.Verdict,
+		Message: submission.Message,
+	}
+
+	if err := answers.Record(attempt); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", submission.Verdict, submission.Message)
+
+	if submission.Verdict != client.Correct {
+		return fmt.Errorf("answer %s was not accepted", *answer)
+	}
+
+	return nil
+}
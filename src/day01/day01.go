@@ -1,174 +1,186 @@
-package main
+package day01
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"log"
-	"math"
-	"os"
-	"strconv"
-	"strings"
-	"unicode"
-	"unicode/utf8"
-)
+	"io"
+	"sort"
 
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+	"github.com/gabrielgry/advent-of-code-2023/src/tokens"
+)
 
-	if err != nil {
-		return nil, err
-	}
+// Mode picks which words count as calibration digits.
+type Mode int
 
-	defer file.Close()
+const (
+	// PlainDigits only reads the decimal digits, as part 1 does.
+	PlainDigits Mode = 1
+	// SpelledDigits also reads the words of the solver's vocabularies, as
+	// part 2 does.
+	SpelledDigits Mode = 2
+)
 
-	scanner := bufio.NewScanner(file)
+var errNoDigits = errors.New("no calibration digits")
 
-	var lines []string
+type Solver struct {
+	lines    []string
+	scanners map[Mode]*tokens.Scanner
+}
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
+func init() {
+	solver.Register(1, func() solver.Solver {
+		s, err := NewSolver(tokens.Digits, tokens.English)
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
+		if err != nil {
+			panic(err)
+		}
 
-	return lines, nil
+		return s
+	})
 }
 
-func mergeDigitMaps(a map[int]rune, b map[int]rune) map[int]rune {
-	merged := make(map[int]rune)
+// NewSolver returns a Solver whose SpelledDigits mode reads the calibration
+// digits from the words of vocabularies. Every word must stand for a single
+// digit.
+func NewSolver(vocabularies ...tokens.Vocabulary) (*Solver, error) {
+	merged := tokens.Merge(vocabularies...)
 
-	for key, value := range a {
-		merged[key] = value
-	}
+	words := make([]string, 0, len(merged))
 
-	for key, value := range b {
-		merged[key] = value
+	for word := range merged {
+		words = append(words, word)
 	}
 
-	return merged
-}
-
-func findPlainDigits(line string) map[int]rune {
-	digits := make(map[int]rune)
+	sort.Strings(words)
 
-	for i, char := range line {
-		if unicode.IsDigit(char) {
-			digits[i] = char
+	for _, word := range words {
+		if merged[word] < 0 || merged[word] > 9 {
+			return nil, fmt.Errorf("word %q stands for %d, not a digit", word, merged[word])
 		}
 	}
 
-	return digits
+	return &Solver{
+		scanners: map[Mode]*tokens.Scanner{
+			PlainDigits:   tokens.NewScanner(tokens.Digits),
+			SpelledDigits: tokens.NewScanner(merged),
+		},
+	}, nil
 }
 
-func findSpelledDigits(line string) map[int]rune {
-	digits := make(map[int]rune)
-
-	digits[strings.Index(line, "one")] = '1'
-	digits[strings.Index(line, "two")] = '2'
-	digits[strings.Index(line, "three")] = '3'
-	digits[strings.Index(line, "four")] = '4'
-	digits[strings.Index(line, "five")] = '5'
-	digits[strings.Index(line, "six")] = '6'
-	digits[strings.Index(line, "seven")] = '7'
-	digits[strings.Index(line, "eight")] = '8'
-	digits[strings.Index(line, "nine")] = '9'
-
-	digits[strings.LastIndex(line, "one")] = '1'
-	digits[strings.LastIndex(line, "two")] = '2'
-	digits[strings.LastIndex(line, "three")] = '3'
-	digits[strings.LastIndex(line, "four")] = '4'
-	digits[strings.LastIndex(line, "five")] = '5'
-	digits[strings.LastIndex(line, "six")] = '6'
-	digits[strings.LastIndex(line, "seven")] = '7'
-	digits[strings.LastIndex(line, "eight")] = '8'
-	digits[strings.LastIndex(line, "nine")] = '9'
-
-	delete(digits, -1)
-
-	return digits
+func (s *Solver) Parse(lines []string) error {
+	s.lines = lines
+	return nil
 }
 
-func findAllDigits(line string) map[int]rune {
-	plainDigits := findPlainDigits(line)
-	spelledDigits := findSpelledDigits(line)
-
-	merged := mergeDigitMaps(plainDigits, spelledDigits)
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.Sum(PlainDigits)
+}
 
-	return merged
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.Sum(SpelledDigits)
 }
 
-func getFirstDigit(digits map[int]rune) rune {
-	smallestIndex := math.MaxInt
-	firstDigit := utf8.RuneError
+// Sum adds up the calibration codes of every line read in mode.
+func (s *Solver) Sum(mode Mode) (solver.Answer, error) {
+	sum, err := getSumOfCodes(s.scanners[mode], s.lines)
 
-	for index := range digits {
-		if index < smallestIndex {
-			smallestIndex = index
-			firstDigit = digits[index]
-		}
+	if err != nil {
+		return nil, input.InDay(1, err)
 	}
 
-	return firstDigit
+	return solver.Int(sum), nil
 }
 
-func getLastDigit(digits map[int]rune) rune {
-	biggestIndex := -1
-	lastDigit := utf8.RuneError
+func (s *Solver) Stream(part int, r io.Reader, maxLineLength int) (solver.Answer, error) {
+	scanner, ok := s.scanners[Mode(part)]
+
+	if !ok {
+		return nil, solver.ErrNotImplemented
+	}
+
+	sum := 0
 
-	for index := range digits {
-		if index > biggestIndex {
-			biggestIndex = index
-			lastDigit = digits[index]
+	err := input.EachLine(r, maxLineLength, func(lineNumber int, line string) error {
+		code, err := findNumberCode(scanner, line)
+
+		if err != nil {
+			return input.Errorf(lineNumber, 0, line, "%w", err)
 		}
+
+		sum = sum + code
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, input.InDay(1, err)
 	}
 
-	return lastDigit
+	return solver.Int(sum), nil
 }
 
-func findNumberCode(line string) (int, error) {
-	digits := findAllDigits(line)
-	firstDigit := getFirstDigit(digits)
-	lastDigit := getLastDigit(digits)
-
-	var fullNumber string
+func findAllDigits(scanner *tokens.Scanner, line string) []tokens.Match {
+	return scanner.FindAll(line)
+}
 
-	if firstDigit != utf8.RuneError {
-		fullNumber = fullNumber + string(firstDigit)
+func getFirstDigit(digits []tokens.Match) (tokens.Match, bool) {
+	if len(digits) == 0 {
+		return tokens.Match{}, false
 	}
 
-	if lastDigit != utf8.RuneError {
-		fullNumber = fullNumber + string(lastDigit)
+	firstDigit := digits[0]
+
+	for i := 1; i < len(digits) && digits[i].Start == firstDigit.Start; i = i + 1 {
+		firstDigit = digits[i]
 	}
 
-	return strconv.Atoi(fullNumber)
+	return firstDigit, true
 }
 
-func getSumOfCodes(lines []string) int {
-	sum := 0
+// getLastDigit returns the match ending last, the longest one when several
+// end together, mirroring getFirstDigit.
+func getLastDigit(digits []tokens.Match) (tokens.Match, bool) {
+	if len(digits) == 0 {
+		return tokens.Match{}, false
+	}
 
-	for _, line := range lines {
-		code, err := findNumberCode(line)
+	lastDigit := digits[0]
 
-		if err != nil {
-			continue
+	for _, digit := range digits[1:] {
+		if digit.End > lastDigit.End || digit.End == lastDigit.End && digit.Start < lastDigit.Start {
+			lastDigit = digit
 		}
-
-		sum = sum + code
 	}
 
-	return sum
+	return lastDigit, true
 }
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/day01/input.txt")
+func findNumberCode(scanner *tokens.Scanner, line string) (int, error) {
+	digits := findAllDigits(scanner, line)
+	firstDigit, foundFirst := getFirstDigit(digits)
+	lastDigit, foundLast := getLastDigit(digits)
 
-	if err != nil {
-		log.Fatal("Could not open the input file")
+	if !foundFirst || !foundLast {
+		return 0, errNoDigits
 	}
 
-	sum := getSumOfCodes(lines)
+	return firstDigit.Value*10 + lastDigit.Value, nil
+}
+
+func getSumOfCodes(scanner *tokens.Scanner, lines []string) (int, error) {
+	sum := 0
+
+	for i, line := range lines {
+		code, err := findNumberCode(scanner, line)
+
+		if err != nil {
+			return 0, input.Errorf(i+1, 0, line, "%w", err)
+		}
+
+		sum = sum + code
+	}
 
-	fmt.Println(sum)
+	return sum, nil
 }
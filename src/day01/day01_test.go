@@ -0,0 +1,44 @@
+package day01_test
+
+import (
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day01"
+	"github.com/gabrielgry/advent-of-code-2023/src/tokens"
+)
+
+func TestVocabularies(t *testing.T) {
+	s, err := day01.NewSolver(tokens.Digits, tokens.Zero, tokens.Roman)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Parse([]string{"zero7", "IX", "a1zero"}); err != nil {
+		t.Fatal(err)
+	}
+
+	answer, err := s.Sum(day01.SpelledDigits)
+
+	if err != nil || answer.String() != "116" {
+		t.Errorf("Sum(SpelledDigits) = %v, %v, want 7 + 99 + 10 = 116", answer, err)
+	}
+
+	roman, err := day01.NewSolver(tokens.Roman)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := roman.Parse([]string{"VIII", "xIVx", "IXVI"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if answer, err := roman.Sum(day01.SpelledDigits); err != nil || answer.String() != "228" {
+		t.Errorf("Sum(SpelledDigits) of roman numerals = %v, %v, want 88 + 44 + 96 = 228", answer, err)
+	}
+
+	if _, err := day01.NewSolver(tokens.Merge(tokens.English, tokens.Vocabulary{"one": 100})); err == nil {
+		t.Errorf("NewSolver with a word standing for 100 succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+	"github.com/gabrielgry/advent-of-code-2023/src/tokens"
+)
+
+// LineTrace is how the calibration code of one line was read. Lines without a
+// code have no First and Last and an Error saying why.
+type LineTrace struct {
+	Line        int            `json:"line"`
+	Text        string         `json:"text"`
+	Tokens      []tokens.Match `json:"tokens"`
+	First       *tokens.Match  `json:"first,omitempty"`
+	Last        *tokens.Match  `json:"last,omitempty"`
+	Code        int            `json:"code"`
+	Contributed bool           `json:"contributed"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// Trace is how the calibration codes of every line were read and summed.
+// A line without a code fails the part, so Sum is then nil and Error is the
+// error the part fails with.
+type Trace struct {
+	Mode  Mode        `json:"mode"`
+	Lines []LineTrace `json:"lines"`
+	Sum   *int        `json:"sum"`
+	Error string      `json:"error,omitempty"`
+}
+
+func (s *Solver) Explain(part int) (solver.Trace, error) {
+	scanner, ok := s.scanners[Mode(part)]
+
+	if !ok {
+		return nil, solver.ErrNotImplemented
+	}
+
+	trace := &Trace{Mode: Mode(part)}
+
+	sum := 0
+
+	for i, line := range s.lines {
+		lineTrace := traceLine(scanner, line)
+		lineTrace.Line = i + 1
+
+		trace.Lines = append(trace.Lines, lineTrace)
+		sum = sum + lineTrace.Code
+	}
+
+	if _, err := s.Sum(Mode(part)); err != nil {
+		trace.Error = err.Error()
+	} else {
+		trace.Sum = &sum
+	}
+
+	return trace, nil
+}
+
+func traceLine(scanner *tokens.Scanner, line string) LineTrace {
+	digits := findAllDigits(scanner, line)
+	lineTrace := LineTrace{Text: line, Tokens: digits}
+
+	code, err := findNumberCode(scanner, line)
+
+	if err != nil {
+		lineTrace.Error = err.Error()
+		return lineTrace
+	}
+
+	firstDigit, _ := getFirstDigit(digits)
+	lastDigit, _ := getLastDigit(digits)
+
+	lineTrace.First = &firstDigit
+	lineTrace.Last = &lastDigit
+	lineTrace.Code = code
+	lineTrace.Contributed = true
+
+	return lineTrace
+}
+
+// WriteText writes a table with a line per input line. Lines without a code
+// are marked with a "!".
+func (t *Trace) WriteText(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(table, "\tline\tcode\tfirst\tlast\ttokens\ttext")
+
+	skipped := 0
+
+	for _, line := range t.Lines {
+		found := make([]string, len(line.Tokens))
+
+		for i, token := range line.Tokens {
+			found[i] = token.String()
+		}
+
+		if !line.Contributed {
+			skipped = skipped + 1
+			fmt.Fprintf(table, "!\t%d\t-\t-\t-\t%s\t%s (%s)\n", line.Line, strings.Join(found, " "), line.Text, line.Error)
+			continue
+		}
+
+		fmt.Fprintf(table, "\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			line.Line, line.Code, line.First, line.Last, strings.Join(found, " "), line.Text)
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if t.Sum == nil {
+		_, err := fmt.Fprintf(w, "no sum, the part fails: %s (%d of %d lines have no code)\n", t.Error, skipped, len(t.Lines))
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "sum %d\n", *t.Sum)
+
+	return err
+}
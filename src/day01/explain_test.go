@@ -0,0 +1,115 @@
+package day01_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day01"
+	"github.com/gabrielgry/advent-of-code-2023/src/tokens"
+)
+
+func explain(t *testing.T, part int, lines ...string) *day01.Trace {
+	t.Helper()
+
+	s, err := day01.NewSolver(tokens.Digits, tokens.English)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Parse(lines); err != nil {
+		t.Fatal(err)
+	}
+
+	trace, err := s.Explain(part)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return trace.(*day01.Trace)
+}
+
+func TestExplainText(t *testing.T) {
+	var text bytes.Buffer
+
+	if err := explain(t, 2, "two1nine", "xtwone3four").WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"  line  code  first  last    tokens                  text",
+		"  1     29    two@0  nine@4  two@0 1@3 nine@4        two1nine",
+		"  2     24    two@1  four@7  two@1 one@3 3@6 four@7  xtwone3four",
+		"sum 53",
+	}
+
+	if got := strings.Split(strings.TrimSuffix(text.String(), "\n"), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("WriteText =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestExplainLineWithoutDigits(t *testing.T) {
+	trace := explain(t, 1, "1abc2", "eightwothree")
+
+	if trace.Sum != nil {
+		t.Errorf("Sum = %d for a part that fails", *trace.Sum)
+	}
+
+	wantError := `day 1: line 2: no calibration digits: "eightwothree"`
+
+	if trace.Error != wantError {
+		t.Errorf("Error = %q, want %q", trace.Error, wantError)
+	}
+
+	var text bytes.Buffer
+
+	if err := trace.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(text.String(), "\n"), "\n")
+
+	if !strings.HasPrefix(lines[2], "!") {
+		t.Errorf("line without digits is not marked: %q", lines[2])
+	}
+
+	if want := "no sum, the part fails: " + wantError + " (1 of 2 lines have no code)"; lines[3] != want {
+		t.Errorf("last line = %q, want %q", lines[3], want)
+	}
+
+	data, err := json.Marshal(trace)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Sum   *int   `json:"sum"`
+		Error string `json:"error"`
+		Lines []struct {
+			Code        int            `json:"code"`
+			Contributed bool           `json:"contributed"`
+			First       *tokens.Match  `json:"first"`
+			Tokens      []tokens.Match `json:"tokens"`
+		} `json:"lines"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Sum != nil || decoded.Error != wantError {
+		t.Errorf("JSON sum = %v and error = %q, want null and %q", decoded.Sum, decoded.Error, wantError)
+	}
+
+	if first := decoded.Lines[0]; !first.Contributed || first.Code != 12 || first.First == nil || len(first.Tokens) != 2 {
+		t.Errorf("JSON line 1 = %+v, want code 12 from 2 tokens", first)
+	}
+
+	if second := decoded.Lines[1]; second.Contributed || second.First != nil || len(second.Tokens) != 0 {
+		t.Errorf("JSON line 2 = %+v, want no code and no tokens", second)
+	}
+}
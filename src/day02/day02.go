@@ -1,51 +1,210 @@
-package main
+package day02
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/day02/input.txt")
+// Draw is the number of cubes of each color shown at once.
+type Draw map[string]int
 
-	if err != nil {
-		log.Fatal("Could not open the input file")
+// Game is a game with its draws in the order they were made.
+type Game struct {
+	ID    int
+	Draws []Draw
+}
+
+// Bag is the number of cubes of each color in the bag.
+type Bag map[string]int
+
+// DefaultBag is the bag part 1 asks about.
+var DefaultBag = Bag{"red": 12, "green": 13, "blue": 14}
+
+// Colors returns the colors in the bag, sorted.
+func (b Bag) Colors() []string {
+	colors := make([]string, 0, len(b))
+
+	for color := range b {
+		colors = append(colors, color)
+	}
+
+	sort.Strings(colors)
+
+	return colors
+}
+
+func (b Bag) String() string {
+	cubes := make([]string, 0, len(b))
+
+	for _, color := range b.Colors() {
+		cubes = append(cubes, strconv.Itoa(b[color])+" "+color)
 	}
 
+	return strings.Join(cubes, ", ")
+}
+
+type Solver struct {
+	bag    Bag
+	games  []Game
+	colors []string
+}
+
+func init() {
+	solver.Register(2, func() solver.Solver { return NewSolver(DefaultBag) })
+}
+
+// NewSolver returns a Solver whose part 1 checks the games against bag.
+func NewSolver(bag Bag) *Solver {
+	return &Solver{bag: bag}
+}
+
+func (s *Solver) Parse(lines []string) error {
+	for index, line := range lines {
+		game, err := parseGame(line, index+1)
+
+		if err != nil {
+			return input.InDay(2, err)
+		}
+
+		s.games = append(s.games, game)
+	}
+
+	colors := make(Bag)
+
+	for _, game := range s.games {
+		for color := range getMinCubeQuantity(game) {
+			colors[color] = 0
+		}
+	}
+
+	s.colors = colors.Colors()
+
+	return nil
+}
+
+// Games returns the parsed games in input order.
+func (s *Solver) Games() []Game {
+	return s.games
+}
+
+// Colors returns every color drawn in the input, sorted.
+func (s *Solver) Colors() []string {
+	return s.colors
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
 	sum := 0
 
-	for _, line := range lines {
-		minCubeQuantity := getGameMinCubeQuantity(line)
+	for _, game := range s.games {
+		sum = sum + s.possibleGameID(game)
+	}
+
+	return solver.Int(sum), nil
+}
 
-		power := minCubeQuantity["red"] * minCubeQuantity["green"] * minCubeQuantity["blue"]
+func (s *Solver) Part2() (solver.Answer, error) {
+	sum := 0
 
-		sum = sum + power
+	for _, game := range s.games {
+		sum = sum + getPower(getMinCubeQuantity(game), s.colors)
 	}
 
-	fmt.Println(sum)
+	return solver.Int(sum), nil
 }
 
-func getGameMinCubeQuantity(line string) map[string]int {
-	_, setsString, _ := strings.Cut(line, ":")
+func (s *Solver) Stream(part int, r io.Reader, maxLineLength int) (solver.Answer, error) {
+	if part != 1 && part != 2 {
+		return nil, solver.ErrNotImplemented
+	}
 
-	minCubeQuantity := map[string]int{"red": 0, "green": 0, "blue": 0}
+	sum := 0
 
-	for _, setString := range strings.Split(setsString, ";") {
-		for _, cubeString := range strings.Split(setString, ",") {
-			cubeCountString, cubeColor, _ := strings.Cut(strings.TrimSpace(cubeString), " ")
+	// The power multiplies every color of the input, so games missing one
+	// of them have no power. Until every color is known, the powers are
+	// summed apart for each set of colors a game draws.
+	colors := make(Bag)
+	powersByColors := make(map[string]int)
 
-			cubeCount, err := strconv.Atoi(cubeCountString)
+	err := input.EachLine(r, maxLineLength, func(lineNumber int, line string) error {
+		game, err := parseGame(line, lineNumber)
 
-			if err != nil {
-				log.Fatal("Could not convert cubeCountString to int")
+		if err != nil {
+			return err
+		}
+
+		if part == 1 {
+			sum = sum + s.possibleGameID(game)
+			return nil
+		}
+
+		minCubeQuantity := getMinCubeQuantity(game)
+		gameColors := minCubeQuantity.Colors()
+		key := strings.Join(gameColors, ",")
+
+		powersByColors[key] = powersByColors[key] + getPower(minCubeQuantity, gameColors)
+
+		for _, color := range gameColors {
+			colors[color] = 0
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, input.InDay(2, err)
+	}
+
+	if part == 2 {
+		sum = powersByColors[strings.Join(colors.Colors(), ",")]
+	}
+
+	return solver.Int(sum), nil
+}
+
+func (s *Solver) possibleGameID(game Game) int {
+	if !game.PossibleWith(s.bag) {
+		return 0
+	}
+
+	return game.ID
+}
+
+// PossibleWith reports whether every draw of the game could have been taken
+// from bag.
+func (g Game) PossibleWith(bag Bag) bool {
+	for _, draw := range g.Draws {
+		for color, count := range draw {
+			if count > bag[color] {
+				return false
 			}
+		}
+	}
+
+	return true
+}
+
+func getPower(minCubeQuantity Bag, colors []string) int {
+	power := 1
 
-			if cubeCount > minCubeQuantity[cubeColor] {
-				minCubeQuantity[cubeColor] = cubeCount
+	for _, color := range colors {
+		power = power * minCubeQuantity[color]
+	}
+
+	return power
+}
+
+func getMinCubeQuantity(game Game) Bag {
+	minCubeQuantity := make(Bag)
+
+	for _, draw := range game.Draws {
+		for color, count := range draw {
+			if current, ok := minCubeQuantity[color]; !ok || count > current {
+				minCubeQuantity[color] = count
 			}
 		}
 	}
@@ -53,26 +212,58 @@ func getGameMinCubeQuantity(line string) map[string]int {
 	return minCubeQuantity
 }
 
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+func parseGame(line string, lineNumber int) (Game, error) {
+	header, drawsString, found := strings.Cut(line, ":")
 
-	if err != nil {
-		return nil, err
+	if !found {
+		return Game{}, input.Errorf(lineNumber, 1, line, "missing \":\" after the game")
 	}
 
-	defer file.Close()
+	idString, found := strings.CutPrefix(header, "Game ")
 
-	scanner := bufio.NewScanner(file)
+	if !found {
+		return Game{}, input.Errorf(lineNumber, 1, header, "missing \"Game\" before the game ID")
+	}
 
-	var lines []string
+	id, err := strconv.Atoi(idString)
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	if err != nil {
+		return Game{}, input.Errorf(lineNumber, len("Game ")+1, idString, "invalid game ID")
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	game := Game{ID: id}
+
+	offset := len(line) - len(drawsString)
+
+	for _, drawString := range strings.Split(drawsString, ";") {
+		draw := make(Draw)
+
+		for _, cubeString := range strings.Split(drawString, ",") {
+			trimmed := strings.TrimSpace(cubeString)
+			column := offset + len(cubeString) - len(strings.TrimLeft(cubeString, " \t")) + 1
+			offset = offset + len(cubeString) + 1
+
+			cubeCountString, cubeColor, _ := strings.Cut(trimmed, " ")
+
+			if cubeCountString == "" {
+				return Game{}, input.Errorf(lineNumber, column, cubeString, "missing cube count")
+			}
+
+			cubeCount, err := strconv.Atoi(cubeCountString)
+
+			if err != nil {
+				return Game{}, input.Errorf(lineNumber, column, cubeCountString, "invalid cube count")
+			}
+
+			if cubeColor == "" {
+				return Game{}, input.Errorf(lineNumber, column, trimmed, "missing cube color")
+			}
+
+			draw[cubeColor] = draw[cubeColor] + cubeCount
+		}
+
+		game.Draws = append(game.Draws, draw)
 	}
 
-	return lines, nil
+	return game, nil
 }
@@ -0,0 +1,441 @@
+package day02
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+// Query answers a question about the parsed games. "minbag" is the smallest
+// bag that makes every game possible; anything else is a condition and
+// selects the games it holds for:
+//
+//	condition = and { "or" and }
+//	and       = not { "and" not }
+//	not       = "not" not | "(" condition ")" | possible | value op value
+//	possible  = "possible" "(" count color { "," count color } ")"
+//	value     = number | "id" | "draws" | ("max" | "min" | "sum") "(" color ")"
+//	op        = "<" | "<=" | ">" | ">=" | "==" | "!="
+//
+// max, min and sum fold the cubes of a color over the draws of a game, so
+// "max(blue) > 10" selects the games that ever showed more than 10 blue
+// cubes.
+func (s *Solver) Query(query string) (solver.Answer, error) {
+	tokens, err := lexQuery(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 2 && tokens[0].text == "minbag" {
+		return getMinBag(s.games), nil
+	}
+
+	p := &queryParser{tokens: tokens}
+	condition, err := p.condition()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != endToken {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	var selection Selection
+
+	for _, game := range s.games {
+		if condition(game) {
+			selection = append(selection, game.ID)
+		}
+	}
+
+	return selection, nil
+}
+
+// Selection is the IDs of the games a query selected.
+type Selection []int
+
+func (s Selection) String() string {
+	if len(s) == 0 {
+		return "no games"
+	}
+
+	ids := make([]string, len(s))
+	sum := 0
+
+	for i, id := range s {
+		ids[i] = strconv.Itoa(id)
+		sum = sum + id
+	}
+
+	games := "games"
+
+	if len(s) == 1 {
+		games = "game"
+	}
+
+	return fmt.Sprintf("%d %s, IDs summing to %d: %s", len(s), games, sum, strings.Join(ids, ", "))
+}
+
+func getMinBag(games []Game) Bag {
+	minBag := make(Bag)
+
+	for _, game := range games {
+		for color, count := range getMinCubeQuantity(game) {
+			if count > minBag[color] {
+				minBag[color] = count
+			}
+		}
+	}
+
+	return minBag
+}
+
+type tokenKind int
+
+const (
+	endToken tokenKind = iota
+	wordToken
+	numberToken
+	symbolToken
+)
+
+type queryToken struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func lexQuery(query string) ([]queryToken, error) {
+	var tokens []queryToken
+
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		start := i
+		char := runes[i]
+
+		switch {
+		case unicode.IsSpace(char):
+			i = i + 1
+			continue
+		case unicode.IsLetter(char):
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || runes[i] == '-' || runes[i] == '_') {
+				i = i + 1
+			}
+
+			tokens = append(tokens, queryToken{kind: wordToken, text: string(runes[start:i]), column: start + 1})
+		case unicode.IsDigit(char):
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i = i + 1
+			}
+
+			tokens = append(tokens, queryToken{kind: numberToken, text: string(runes[start:i]), column: start + 1})
+		case strings.ContainsRune("(),", char):
+			i = i + 1
+			tokens = append(tokens, queryToken{kind: symbolToken, text: string(char), column: start + 1})
+		case strings.ContainsRune("<>=!", char):
+			i = i + 1
+
+			if i < len(runes) && runes[i] == '=' {
+				i = i + 1
+			}
+
+			text := string(runes[start:i])
+
+			if text == "=" || text == "!" {
+				return nil, fmt.Errorf("query column %d: invalid operator %q", start+1, text)
+			}
+
+			tokens = append(tokens, queryToken{kind: symbolToken, text: text, column: start + 1})
+		default:
+			return nil, fmt.Errorf("query column %d: unexpected %q", start+1, string(char))
+		}
+	}
+
+	return append(tokens, queryToken{kind: endToken, text: "end of query", column: len(runes) + 1}), nil
+}
+
+type predicate func(game Game) bool
+
+type valueFunc func(game Game) int
+
+type queryParser struct {
+	tokens   []queryToken
+	position int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.position]
+}
+
+func (p *queryParser) next() queryToken {
+	token := p.tokens[p.position]
+
+	if token.kind != endToken {
+		p.position = p.position + 1
+	}
+
+	return token
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("query column %d: %s", p.peek().column, fmt.Sprintf(format, args...))
+}
+
+func (p *queryParser) expect(text string) error {
+	if p.peek().text != text || p.peek().kind == endToken {
+		return p.errorf("expected %q, found %q", text, p.peek().text)
+	}
+
+	p.next()
+
+	return nil
+}
+
+func (p *queryParser) condition() (predicate, error) {
+	left, err := p.and()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == wordToken && p.peek().text == "or" {
+		p.next()
+
+		right, err := p.and()
+
+		if err != nil {
+			return nil, err
+		}
+
+		either := left
+		left = func(game Game) bool { return either(game) || right(game) }
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) and() (predicate, error) {
+	left, err := p.not()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == wordToken && p.peek().text == "and" {
+		p.next()
+
+		right, err := p.not()
+
+		if err != nil {
+			return nil, err
+		}
+
+		both := left
+		left = func(game Game) bool { return both(game) && right(game) }
+	}
+
+	return left, nil
+}
+
+func (p *queryParser) not() (predicate, error) {
+	token := p.peek()
+
+	switch {
+	case token.kind == wordToken && token.text == "not":
+		p.next()
+
+		negated, err := p.not()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return func(game Game) bool { return !negated(game) }, nil
+	case token.kind == symbolToken && token.text == "(":
+		p.next()
+
+		condition, err := p.condition()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return condition, p.expect(")")
+	case token.kind == wordToken && token.text == "possible":
+		p.next()
+
+		return p.possible()
+	}
+
+	return p.comparison()
+}
+
+func (p *queryParser) possible() (predicate, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	bag := make(Bag)
+
+	for {
+		if p.peek().kind != numberToken {
+			return nil, p.errorf("expected a cube count, found %q", p.peek().text)
+		}
+
+		count, err := strconv.Atoi(p.next().text)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek().kind != wordToken {
+			return nil, p.errorf("expected a color, found %q", p.peek().text)
+		}
+
+		color := p.next().text
+		bag[color] = count
+
+		if p.peek().text != "," {
+			break
+		}
+
+		p.next()
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return func(game Game) bool { return game.PossibleWith(bag) }, nil
+}
+
+var comparisons = map[string]func(a int, b int) bool{
+	"<":  func(a int, b int) bool { return a < b },
+	"<=": func(a int, b int) bool { return a <= b },
+	">":  func(a int, b int) bool { return a > b },
+	">=": func(a int, b int) bool { return a >= b },
+	"==": func(a int, b int) bool { return a == b },
+	"!=": func(a int, b int) bool { return a != b },
+}
+
+func (p *queryParser) comparison() (predicate, error) {
+	left, err := p.value()
+
+	if err != nil {
+		return nil, err
+	}
+
+	compare, ok := comparisons[p.peek().text]
+
+	if !ok || p.peek().kind != symbolToken {
+		return nil, p.errorf("expected a comparison, found %q", p.peek().text)
+	}
+
+	p.next()
+
+	right, err := p.value()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(game Game) bool { return compare(left(game), right(game)) }, nil
+}
+
+var folds = map[string]func(game Game, color string) int{
+	"max": func(game Game, color string) int {
+		return getMinCubeQuantity(game)[color]
+	},
+	"min": func(game Game, color string) int {
+		least := -1
+
+		for _, draw := range game.Draws {
+			if least == -1 || draw[color] < least {
+				least = draw[color]
+			}
+		}
+
+		return least
+	},
+	"sum": func(game Game, color string) int {
+		sum := 0
+
+		for _, draw := range game.Draws {
+			sum = sum + draw[color]
+		}
+
+		return sum
+	},
+}
+
+func (p *queryParser) value() (valueFunc, error) {
+	token := p.peek()
+
+	if token.kind == numberToken {
+		p.next()
+
+		number, err := strconv.Atoi(token.text)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return func(game Game) int { return number }, nil
+	}
+
+	if token.kind != wordToken {
+		return nil, p.errorf("expected a value, found %q", token.text)
+	}
+
+	switch token.text {
+	case "id":
+		p.next()
+		return func(game Game) int { return game.ID }, nil
+	case "draws":
+		p.next()
+		return func(game Game) int { return len(game.Draws) }, nil
+	}
+
+	fold, ok := folds[token.text]
+
+	if !ok {
+		return nil, p.errorf("unknown value %q, expected a number, id, draws or one of %s", token.text, strings.Join(foldNames(), ", "))
+	}
+
+	p.next()
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != wordToken {
+		return nil, p.errorf("expected a color, found %q", p.peek().text)
+	}
+
+	color := p.next().text
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return func(game Game) int { return fold(game, color) }, nil
+}
+
+func foldNames() []string {
+	names := make([]string, 0, len(folds))
+
+	for name := range folds {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,87 @@
+package day02_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day02"
+)
+
+var example = []string{
+	"Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green",
+	"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue",
+	"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red",
+	"Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red",
+	"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
+}
+
+func parseExample(t *testing.T) *day02.Solver {
+	t.Helper()
+
+	s := day02.NewSolver(day02.DefaultBag)
+
+	if err := s.Parse(example); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestQuery(t *testing.T) {
+	s := parseExample(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"minbag", "15 blue, 13 green, 20 red"},
+		{"possible(12 red, 13 green, 14 blue)", "3 games, IDs summing to 8: 1, 2, 5"},
+		{"max(blue) > 10", "1 game, IDs summing to 4: 4"},
+		{"not (id == 1 or draws < 3) and sum(red) >= 10", "2 games, IDs summing to 7: 3, 4"},
+		{"min(green) == 0 and max(green) > 0", "1 game, IDs summing to 1: 1"},
+		{"max(purple) > 0", "no games"},
+	}
+
+	for _, test := range tests {
+		answer, err := s.Query(test.query)
+
+		if err != nil {
+			t.Errorf("Query(%q) error = %v", test.query, err)
+			continue
+		}
+
+		if answer.String() != test.want {
+			t.Errorf("Query(%q) = %s, want %s", test.query, answer, test.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	s := parseExample(t)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"max(blue) >", `query column 12: expected a value, found "end of query"`},
+		{"possible(red)", `query column 10: expected a cube count, found "red"`},
+		{"x = 1", `query column 3: invalid operator "="`},
+		{"id < 3 blue", `query column 8: unexpected "blue"`},
+		{"max(blue > 1", `query column 10: expected ")", found ">"`},
+		{"median(red) > 1", `query column 1: unknown value "median", expected a number, id, draws or one of max, min, sum`},
+		{"id ? 1", `query column 4: unexpected "?"`},
+	}
+
+	for _, test := range tests {
+		answer, err := s.Query(test.query)
+
+		if err == nil {
+			t.Errorf("Query(%q) = %s, want an error", test.query, answer)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Query(%q) error = %q, want %q", test.query, err, test.want)
+		}
+	}
+}
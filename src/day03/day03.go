@@ -1,27 +1,28 @@
-package main
+package day03
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"errors"
 	"strconv"
-	"strings"
 	"unicode"
-	"unicode/utf8"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/grid"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
 type FoundNumber struct {
-	Number int
-	Line   int
-	Start  int
-	End    int
+	Number  int
+	Line    int
+	Start   int
+	End     int
+	Symbols []Symbol
 }
 
-type GearConnection struct {
-	Number int
-	X      int
-	Y      int
+// Symbol is a cell of the schematic that is neither a digit nor a '.'.
+type Symbol struct {
+	Char rune
+	X    int
+	Y    int
 }
 
 type Gear struct {
@@ -30,116 +31,101 @@ type Gear struct {
 	Numbers []int
 }
 
-func findNumbers(line string, lineIndex int) []FoundNumber {
-	var foundNumbers []FoundNumber
+type Solver struct {
+	schematic    *grid.Grid[rune]
+	foundNumbers [][]FoundNumber
+	index        *Index
+}
 
-	numberString := ""
-	startIndex := -1
-	endIndex := -1
+func init() {
+	solver.Register(3, func() solver.Solver { return &Solver{} })
+}
 
-	for index, char := range line {
-		lineCharCount := utf8.RuneCountInString(line)
+func (s *Solver) Parse(lines []string) error {
+	schematic, err := grid.FromLines(lines)
 
-		if unicode.IsDigit(char) {
-			if numberString == "" {
-				startIndex = index
-			}
+	var raggedError *grid.RaggedError
 
-			numberString = numberString + string(char)
+	if errors.As(err, &raggedError) {
+		line := raggedError.Row + 1
+		return input.InDay(3, input.Errorf(line, 0, lines[line-1], "expected %d cells like the first line", raggedError.Want))
+	}
 
-			if index >= lineCharCount-1 {
-				endIndex = index
-				number, _ := strconv.Atoi(numberString)
-				foundNumber := FoundNumber{Number: number, Line: lineIndex, Start: startIndex, End: endIndex}
-				foundNumbers = append(foundNumbers, foundNumber)
-				numberString = ""
-				startIndex = -1
-				endIndex = -1
-			}
+	foundNumbers, err := findNumbers(schematic)
 
-			continue
-		} else if numberString != "" {
-			endIndex = index - 1
-			number, _ := strconv.Atoi(numberString)
-			foundNumber := FoundNumber{Number: number, Line: lineIndex, Start: startIndex, End: endIndex}
-			foundNumbers = append(foundNumbers, foundNumber)
-			numberString = ""
-			startIndex = -1
-			endIndex = -1
-		}
+	if err != nil {
+		return input.InDay(3, err)
 	}
 
-	return foundNumbers
+	s.schematic = schematic
+	s.foundNumbers = foundNumbers
+	s.index = NewIndex(schematic, foundNumbers)
+
+	return nil
 }
 
-func hasSymbolOnRange(line string, lineIndex int, foundNumber FoundNumber) (bool, []GearConnection) {
-	var gears []GearConnection
+// Index returns the index of the symbols of the parsed schematic.
+func (s *Solver) Index() *Index {
+	return s.index
+}
 
-	for index, char := range line {
-		if index < foundNumber.Start-1 || index > foundNumber.End+1 || unicode.IsDigit(char) || char == '.' {
-			continue
-		}
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Int(partNumbersSum(s.foundNumbers)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Int(getGearRatioSum(s.index.Gears())), nil
+}
+
+func findNumbers(schematic *grid.Grid[rune]) ([][]FoundNumber, error) {
+	foundNumbers := make([][]FoundNumber, schematic.Height())
 
-		if char == '*' {
-			gears = append(gears, GearConnection{Number: foundNumber.Number, X: index, Y: lineIndex})
+	for _, segment := range schematic.Segments(unicode.IsDigit) {
+		numberString := string(schematic.Cells(segment))
+		number, err := strconv.Atoi(numberString)
+
+		if err != nil {
+			return nil, input.Errorf(segment.Y+1, segment.Start+1, numberString, "invalid number")
 		}
 
-		return true, gears
+		foundNumber := FoundNumber{Number: number, Line: segment.Y, Start: segment.Start, End: segment.End}
+		foundNumber.Symbols = findAdjacentSymbols(schematic, foundNumber)
+		foundNumbers[segment.Y] = append(foundNumbers[segment.Y], foundNumber)
 	}
 
-	return false, gears
+	return foundNumbers, nil
 }
 
-func extractPartNumbers(foundNumbers []FoundNumber, lines []string) ([]int, []GearConnection) {
-	var partNumbers []int
-	var gears []GearConnection
+// findAdjacentSymbols returns every symbol in the 8 cells around any digit
+// of foundNumber, row by row.
+func findAdjacentSymbols(schematic *grid.Grid[rune], foundNumber FoundNumber) []Symbol {
+	var symbols []Symbol
 
-	for _, foundNumber := range foundNumbers {
-		isPartNumber, foundGears := hasSymbolOnRange(lines[foundNumber.Line], foundNumber.Line, foundNumber)
+	segment := grid.Segment{Y: foundNumber.Line, Start: foundNumber.Start, End: foundNumber.End}
 
-		if !isPartNumber && foundNumber.Line > 0 {
-			isPartNumber, foundGears = hasSymbolOnRange(lines[foundNumber.Line-1], foundNumber.Line-1, foundNumber)
-		}
+	for _, point := range schematic.Around(segment) {
+		char := schematic.At(point)
 
-		if !isPartNumber && foundNumber.Line < len(lines)-1 {
-			isPartNumber, foundGears = hasSymbolOnRange(lines[foundNumber.Line+1], foundNumber.Line+1, foundNumber)
+		if unicode.IsDigit(char) || char == '.' {
+			continue
 		}
 
-		if isPartNumber {
-			partNumbers = append(partNumbers, foundNumber.Number)
-			gears = append(gears, foundGears...)
-		}
+		symbols = append(symbols, Symbol{Char: char, X: point.X, Y: point.Y})
 	}
 
-	return partNumbers, gears
+	return symbols
 }
 
-func mergeGearConnections(gearConnections []GearConnection) []Gear {
-	var gears []Gear
-
-	for _, gearConnection := range gearConnections {
-		updatedGear := false
-
-		for i, gear := range gears {
-			if gear.X == gearConnection.X && gear.Y == gearConnection.Y {
-				gears[i].Numbers = append(gear.Numbers, gearConnection.Number)
-				updatedGear = true
-			}
-		}
-
-		if !updatedGear {
-			var gear = Gear{
-				X:       gearConnection.X,
-				Y:       gearConnection.Y,
-				Numbers: []int{gearConnection.Number},
-			}
+func extractPartNumbers(foundNumbers []FoundNumber) []int {
+	var partNumbers []int
 
-			gears = append(gears, gear)
+	for _, foundNumber := range foundNumbers {
+		if len(foundNumber.Symbols) != 0 {
+			partNumbers = append(partNumbers, foundNumber.Number)
 		}
-
 	}
 
-	return gears
+	return partNumbers
 }
 
 func getGearRatioSum(gears []Gear) int {
@@ -154,66 +140,14 @@ func getGearRatioSum(gears []Gear) int {
 	return sum
 }
 
-func partNumbersSum(lines []string) (int, int) {
+func partNumbersSum(foundNumbersByLine [][]FoundNumber) int {
 	sum := 0
-	var gearConnections []GearConnection
-	possibleGears := 0
-
-	for lineIndex, line := range lines {
-		foundNumbers := findNumbers(line, lineIndex)
-		partNumbers, foundGearConnections := extractPartNumbers(foundNumbers, lines)
 
-		gearConnections = append(gearConnections, foundGearConnections...)
-
-		for _, partNumber := range partNumbers {
+	for _, foundNumbers := range foundNumbersByLine {
+		for _, partNumber := range extractPartNumbers(foundNumbers) {
 			sum = sum + partNumber
 		}
-
-		possibleGears = possibleGears + strings.Count(line, "*")
-	}
-
-	fmt.Print(possibleGears)
-
-	gears := mergeGearConnections(gearConnections)
-
-	gearRatioSum := getGearRatioSum(gears)
-
-	return sum, gearRatioSum
-}
-
-func main() {
-	lines, err := readLinesFromFile("../../inputs/day03/input.txt")
-
-	if err != nil {
-		log.Fatal("Could not open the input file")
-	}
-
-	sum, gearRatioSum := partNumbersSum(lines)
-
-	fmt.Printf("Parts sum: %d\n", sum)
-	fmt.Printf("Gear ration sum: %d\n", gearRatioSum)
-}
-
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	var lines []string
-
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return lines, nil
+	return sum
 }
@@ -0,0 +1,84 @@
+package day03
+
+import (
+	"unicode"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/grid"
+)
+
+// IndexedSymbol is a symbol with the numbers adjacent to it.
+type IndexedSymbol struct {
+	Symbol
+	Numbers []int
+}
+
+// Index maps every symbol of a schematic to the numbers adjacent to it.
+type Index struct {
+	symbols []IndexedSymbol
+	byPoint map[grid.Point]int
+}
+
+// NewIndex indexes every symbol of schematic, reading row by row, with the
+// numbers found next to it.
+func NewIndex(schematic *grid.Grid[rune], foundNumbersByLine [][]FoundNumber) *Index {
+	index := &Index{byPoint: make(map[grid.Point]int)}
+
+	for y := 0; y < schematic.Height(); y = y + 1 {
+		for x, char := range schematic.Row(y) {
+			if unicode.IsDigit(char) || char == '.' {
+				continue
+			}
+
+			index.byPoint[grid.Point{X: x, Y: y}] = len(index.symbols)
+			index.symbols = append(index.symbols, IndexedSymbol{Symbol: Symbol{Char: char, X: x, Y: y}})
+		}
+	}
+
+	for _, foundNumbers := range foundNumbersByLine {
+		for _, foundNumber := range foundNumbers {
+			for _, symbol := range foundNumber.Symbols {
+				position := index.byPoint[grid.Point{X: symbol.X, Y: symbol.Y}]
+				index.symbols[position].Numbers = append(index.symbols[position].Numbers, foundNumber.Number)
+			}
+		}
+	}
+
+	return index
+}
+
+// At returns the symbol at x and y, or false when there is none.
+func (i *Index) At(x int, y int) (IndexedSymbol, bool) {
+	position, ok := i.byPoint[grid.Point{X: x, Y: y}]
+
+	if !ok {
+		return IndexedSymbol{}, false
+	}
+
+	return i.symbols[position], true
+}
+
+// Symbols returns the symbols written as char with exactly count adjacent
+// numbers, in reading order. A zero char matches every symbol and a negative
+// count any number of adjacent numbers.
+func (i *Index) Symbols(char rune, count int) []IndexedSymbol {
+	var symbols []IndexedSymbol
+
+	for _, symbol := range i.symbols {
+		if (char == 0 || symbol.Char == char) && (count < 0 || len(symbol.Numbers) == count) {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols
+}
+
+// Gears returns the '*' symbols adjacent to exactly two numbers.
+func (i *Index) Gears() []Gear {
+	var gears []Gear
+
+	for _, symbol := range i.Symbols('*', 2) {
+		gears = append(gears, Gear{X: symbol.X, Y: symbol.Y, Numbers: symbol.Numbers})
+	}
+
+	return gears
+}
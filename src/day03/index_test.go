@@ -0,0 +1,56 @@
+package day03_test
+
+import (
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day03"
+)
+
+func TestIndex(t *testing.T) {
+	s := &day03.Solver{}
+
+	err := s.Parse([]string{
+		"467..114..",
+		"...*......",
+		"..35..633.",
+		"......#...",
+		"617*......",
+		".....+.58.",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := s.Index()
+
+	if symbol, ok := index.At(3, 1); !ok || symbol.Char != '*' || len(symbol.Numbers) != 2 {
+		t.Errorf("At(3, 1) = %+v, %v, want a '*' with 2 numbers", symbol, ok)
+	}
+
+	if _, ok := index.At(0, 0); ok {
+		t.Errorf("At(0, 0) found a symbol on a digit")
+	}
+
+	tests := []struct {
+		char  rune
+		count int
+		want  int
+	}{
+		{'*', 2, 1},
+		{'*', 1, 1},
+		{'+', 0, 1},
+		{0, -1, 4},
+		{0, 1, 2},
+	}
+
+	for _, test := range tests {
+		if got := len(index.Symbols(test.char, test.count)); got != test.want {
+			t.Errorf("len(Symbols(%q, %d)) = %d, want %d", test.char, test.count, got, test.want)
+		}
+	}
+
+	if gears := index.Gears(); len(gears) != 1 || gears[0].X != 3 || gears[0].Y != 1 {
+		t.Errorf("Gears() = %+v, want the gear at (3, 1)", gears)
+	}
+}
@@ -0,0 +1,155 @@
+package day03
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/grid"
+)
+
+type cellStyle int
+
+const (
+	plainCell cellStyle = iota
+	partNumberCell
+	otherNumberCell
+	symbolCell
+	gearCell
+)
+
+var ansiColors = map[cellStyle]string{
+	partNumberCell:  "\x1b[32m",
+	otherNumberCell: "\x1b[31m",
+	symbolCell:      "\x1b[36m",
+	gearCell:        "\x1b[1;33m",
+}
+
+const ansiReset = "\x1b[0m"
+
+var htmlClasses = map[cellStyle]string{
+	partNumberCell:  "part",
+	otherNumberCell: "other",
+	symbolCell:      "symbol",
+	gearCell:        "gear",
+}
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Schematic</title>
+<style>
+pre { font-family: monospace; line-height: 1.2; }
+.part { color: #1a7f37; }
+.other { color: #cf222e; }
+.symbol { color: #0969da; }
+.gear { color: #9a6700; font-weight: bold; background: #fff8c5; }
+</style>
+</head>
+<body>
+<p><span class="part">part numbers</span>, <span class="other">other numbers</span>, <span class="symbol">symbols</span> and <span class="gear">gears</span></p>
+<pre>
+`
+
+const htmlFooter = `</pre>
+</body>
+</html>
+`
+
+// Render writes the schematic back out with part numbers, other numbers,
+// symbols and gears highlighted, either with ANSI colors for a terminal
+// ("ansi") or as an HTML page ("html").
+func (s *Solver) Render(w io.Writer, format string) error {
+	styles := s.cellStyles()
+
+	switch format {
+	case "ansi":
+		return renderRows(w, s.schematic, styles, func(style cellStyle, text string) string {
+			if style == plainCell {
+				return text
+			}
+
+			return ansiColors[style] + text + ansiReset
+		})
+	case "html":
+		if _, err := io.WriteString(w, htmlHeader); err != nil {
+			return err
+		}
+
+		err := renderRows(w, s.schematic, styles, func(style cellStyle, text string) string {
+			if style == plainCell {
+				return html.EscapeString(text)
+			}
+
+			return `<span class="` + htmlClasses[style] + `">` + html.EscapeString(text) + "</span>"
+		})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(w, htmlFooter)
+
+		return err
+	}
+
+	return fmt.Errorf("unknown format %q, expected ansi or html", format)
+}
+
+func (s *Solver) cellStyles() *grid.Grid[cellStyle] {
+	styles := grid.New(s.schematic.Width(), s.schematic.Height(), plainCell)
+
+	for _, foundNumbers := range s.foundNumbers {
+		for _, foundNumber := range foundNumbers {
+			style := otherNumberCell
+
+			if len(foundNumber.Symbols) != 0 {
+				style = partNumberCell
+			}
+
+			for x := foundNumber.Start; x <= foundNumber.End; x = x + 1 {
+				styles.Set(grid.Point{X: x, Y: foundNumber.Line}, style)
+			}
+		}
+	}
+
+	for _, symbol := range s.index.Symbols(0, -1) {
+		styles.Set(grid.Point{X: symbol.X, Y: symbol.Y}, symbolCell)
+	}
+
+	for _, gear := range s.index.Gears() {
+		styles.Set(grid.Point{X: gear.X, Y: gear.Y}, gearCell)
+	}
+
+	return styles
+}
+
+// renderRows writes every row of the schematic, passing each run of cells
+// with the same style through decorate.
+func renderRows(w io.Writer, schematic *grid.Grid[rune], styles *grid.Grid[cellStyle], decorate func(style cellStyle, text string) string) error {
+	for y := 0; y < schematic.Height(); y = y + 1 {
+		row := schematic.Row(y)
+		rowStyles := styles.Row(y)
+		var line strings.Builder
+		start := 0
+
+		for x := 1; x <= len(row); x = x + 1 {
+			if x < len(row) && rowStyles[x] == rowStyles[start] {
+				continue
+			}
+
+			line.WriteString(decorate(rowStyles[start], string(row[start:x])))
+			start = x
+		}
+
+		line.WriteString("\n")
+
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package day03_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day03"
+)
+
+func TestRender(t *testing.T) {
+	s := &day03.Solver{}
+
+	if err := s.Parse([]string{"467..114..", "...*......", "..35..633."}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ansi bytes.Buffer
+
+	if err := s.Render(&ansi, "ansi"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "\x1b[32m467\x1b[0m..\x1b[31m114\x1b[0m..\n...\x1b[1;33m*\x1b[0m......\n..\x1b[32m35\x1b[0m..\x1b[31m633\x1b[0m.\n"
+
+	if ansi.String() != want {
+		t.Errorf("ANSI rendering = %q, want %q", ansi.String(), want)
+	}
+
+	var html bytes.Buffer
+
+	if err := s.Render(&html, "html"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(html.String(), `...<span class="gear">*</span>......`) {
+		t.Errorf("HTML rendering does not highlight the gear:\n%s", html.String())
+	}
+
+	if err := s.Render(&html, "pdf"); err == nil {
+		t.Errorf("Render with an unknown format succeeded")
+	}
+}
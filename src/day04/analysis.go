@@ -0,0 +1,309 @@
+package day04
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+// Contribution is what one card adds to the pile of scratchcards.
+type Contribution struct {
+	Id           int
+	WinningCount int
+	// Quantity is how many copies of the card end up in the pile.
+	Quantity *big.Int
+	// Cards is how many cards a single copy of the card adds to the pile,
+	// itself and every copy it wins, directly or not, included. The Cards
+	// of all the original cards add up to the whole pile.
+	Cards *big.Int
+}
+
+// Analysis explains how the scratchcards multiply, optionally with some
+// cards winning a different number of matches than they really do.
+type Analysis struct {
+	cardPool CardPool
+	// cards holds Contribution.Cards in the order of cardPool.
+	cards []*big.Int
+}
+
+// Analyze runs the card cascade with the winning counts of the cards in
+// overrides replaced by the given ones.
+func Analyze(cards []Card, overrides map[int]int) (*Analysis, error) {
+	cardPool := createCardPool(cards)
+
+	for id, winningCount := range overrides {
+		index, ok := cardPool.index(id)
+
+		if !ok {
+			return nil, fmt.Errorf("no card %d", id)
+		}
+
+		if winningCount < 0 {
+			return nil, fmt.Errorf("card %d cannot win %d matches", id, winningCount)
+		}
+
+		cardPool[index].Card.WinningCount = winningCount
+	}
+
+	analysis := &Analysis{cardPool: processCardPool(cardPool), cards: make([]*big.Int, len(cardPool))}
+
+	// A copy of a card is worth itself plus what the copies it wins are
+	// worth. Walking the cards backwards with a running suffix sum of those
+	// worths gives each card's worth from the ones after it.
+	suffixSums := make([]*big.Int, len(cardPool)+1)
+	suffixSums[len(cardPool)] = new(big.Int)
+
+	for index := len(cardPool) - 1; index >= 0; index = index - 1 {
+		end := cardPool.lastWon(index)
+
+		cards := big.NewInt(1)
+		cards.Add(cards, suffixSums[index+1])
+		cards.Sub(cards, suffixSums[end+1])
+
+		analysis.cards[index] = cards
+		suffixSums[index] = new(big.Int).Add(suffixSums[index+1], cards)
+	}
+
+	return analysis, nil
+}
+
+// CardPool returns the stacks of every card after all the copies were won.
+func (a *Analysis) CardPool() CardPool {
+	return a.cardPool
+}
+
+// Total returns the number of cards in the pile.
+func (a *Analysis) Total() *big.Int {
+	return countCards(a.cardPool)
+}
+
+// Contributions returns the contribution of every card, by ID.
+func (a *Analysis) Contributions() []Contribution {
+	var contributions []Contribution
+
+	for index, cardStack := range a.cardPool {
+		contributions = append(contributions, Contribution{
+			Id:           cardStack.Card.Id,
+			WinningCount: cardStack.Card.WinningCount,
+			Quantity:     cardStack.Quantity,
+			Cards:        a.cards[index],
+		})
+	}
+
+	return contributions
+}
+
+// Top returns the n cards that add the most to the pile, largest first, or
+// none when n is not positive.
+func (a *Analysis) Top(n int) []Contribution {
+	contributions := a.Contributions()
+
+	if n < 0 {
+		n = 0
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Cards.Cmp(contributions[j].Cards) > 0
+	})
+
+	if n < len(contributions) {
+		contributions = contributions[:n]
+	}
+
+	return contributions
+}
+
+// MaxCopyTreeLines is the most cards WriteCopyTree writes, as a copy tree
+// has as many cards as the copies it wins.
+const MaxCopyTreeLines = 10000
+
+// WriteCopyTree writes the tree of copies a single copy of card id wins,
+// one card per line indented by depth, down to maxDepth levels below it and
+// cut after MaxCopyTreeLines cards.
+func (a *Analysis) WriteCopyTree(w io.Writer, id int, maxDepth int) error {
+	if maxDepth < 0 {
+		return fmt.Errorf("invalid depth %d", maxDepth)
+	}
+
+	index, ok := a.cardPool.index(id)
+
+	if !ok {
+		return fmt.Errorf("no card %d", id)
+	}
+
+	lines := 0
+
+	return a.writeCopyTree(w, index, 0, maxDepth, &lines)
+}
+
+func (a *Analysis) writeCopyTree(w io.Writer, index int, depth int, maxDepth int, lines *int) error {
+	if *lines == MaxCopyTreeLines {
+		*lines = *lines + 1
+		_, err := fmt.Fprintf(w, "%s... cut after %d cards\n", strings.Repeat("  ", depth), MaxCopyTreeLines)
+		return err
+	}
+
+	if *lines > MaxCopyTreeLines {
+		return nil
+	}
+
+	*lines = *lines + 1
+
+	cards := "cards"
+
+	if a.cards[index].IsInt64() && a.cards[index].Int64() == 1 {
+		cards = "card"
+	}
+
+	card := a.cardPool[index].Card
+	_, err := fmt.Fprintf(w, "%sCard %d: %d matches, %d %s\n", strings.Repeat("  ", depth), card.Id, card.WinningCount, a.cards[index], cards)
+
+	if err != nil {
+		return err
+	}
+
+	end := a.cardPool.lastWon(index)
+
+	if depth == maxDepth && end > index {
+		_, err := fmt.Fprintf(w, "%s...\n", strings.Repeat("  ", depth+1))
+		return err
+	}
+
+	for wonIndex := index + 1; wonIndex <= end; wonIndex = wonIndex + 1 {
+		if err := a.writeCopyTree(w, wonIndex, depth+1, maxDepth, lines); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Query analyzes the card cascade. The query starts with one of
+//
+//	total            the number of cards in the pile
+//	top [n]          the n cards, 5 by default, adding the most to the pile
+//	tree <id> [n]    the copies card id wins, n levels deep, 3 by default
+//
+// followed by any number of overrides changing how many matches a card
+// wins: "17+1" and "17-1" add to or take from card 17's matches and "17=3"
+// sets them, so "total 17+1" is the pile if card 17 had one more match.
+func (s *Solver) Query(query string) (solver.Answer, error) {
+	words := strings.Fields(query)
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	var arguments []int
+
+	overrides := make(map[int]int)
+
+	for _, word := range words[1:] {
+		if index := strings.IndexAny(word, "+-="); index > 0 {
+			if err := s.addOverride(overrides, word[:index], word[index], word[index+1:]); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		argument, err := strconv.Atoi(word)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %q", word)
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	analysis, err := Analyze(s.cards, overrides)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var report strings.Builder
+
+	switch {
+	case words[0] == "total" && len(arguments) == 0:
+		return analysis.Total(), nil
+	case words[0] == "top" && len(arguments) <= 1:
+		n := 5
+
+		if len(arguments) == 1 {
+			n = arguments[0]
+		}
+
+		if n < 0 {
+			return nil, fmt.Errorf("invalid number of cards %d", n)
+		}
+
+		for i, contribution := range analysis.Top(n) {
+			if i > 0 {
+				report.WriteString("\n")
+			}
+
+			fmt.Fprintf(&report, "Card %d: %d matches, %d copies, adds %d cards", contribution.Id,
+				contribution.WinningCount, contribution.Quantity, contribution.Cards)
+		}
+	case words[0] == "tree" && (len(arguments) == 1 || len(arguments) == 2):
+		maxDepth := 3
+
+		if len(arguments) == 2 {
+			maxDepth = arguments[1]
+		}
+
+		if maxDepth < 0 {
+			return nil, fmt.Errorf("invalid depth %d", maxDepth)
+		}
+
+		if err := analysis.WriteCopyTree(&report, arguments[0], maxDepth); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid query %q, expected total, top [n] or tree <id> [n]", query)
+	}
+
+	return queryReport(strings.TrimSuffix(report.String(), "\n")), nil
+}
+
+func (s *Solver) addOverride(overrides map[int]int, idString string, operator byte, valueString string) error {
+	id, idErr := strconv.Atoi(idString)
+	value, valueErr := strconv.Atoi(valueString)
+
+	if idErr != nil || valueErr != nil {
+		return fmt.Errorf("invalid override %q", idString+string(operator)+valueString)
+	}
+
+	winningCount, ok := overrides[id]
+
+	if !ok {
+		for _, card := range s.cards {
+			if card.Id == id {
+				winningCount = card.WinningCount
+			}
+		}
+	}
+
+	switch operator {
+	case '+':
+		overrides[id] = winningCount + value
+	case '-':
+		overrides[id] = winningCount - value
+	case '=':
+		overrides[id] = value
+	}
+
+	return nil
+}
+
+type queryReport string
+
+func (r queryReport) String() string {
+	return string(r)
+}
@@ -0,0 +1,83 @@
+package day04_test
+
+import (
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day04"
+)
+
+var example = []string{
+	"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+	"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+	"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+	"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+	"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+	"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+}
+
+func TestAnalysis(t *testing.T) {
+	s := &day04.Solver{}
+
+	if err := s.Parse(example); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := day04.Analyze(s.Cards(), nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := 0
+
+	for _, contribution := range analysis.Contributions() {
+		sum = sum + int(contribution.Cards.Int64())
+	}
+
+	if sum != 30 || analysis.Total().Int64() != 30 {
+		t.Errorf("contributions add up to %d and the total is %d, want 30", sum, analysis.Total())
+	}
+
+	if top := analysis.Top(1); len(top) != 1 || top[0].Id != 1 || top[0].Cards.Int64() != 15 {
+		t.Errorf("Top(1) = %+v, want card 1 adding 15 cards", top)
+	}
+
+	if top := analysis.Top(-1); len(top) != 0 {
+		t.Errorf("Top(-1) = %+v, want no cards", top)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"total", "30"},
+		{"total 1+1", "31"},
+		{"total 4=0", "22"},
+		{"tree 4", "Card 4: 1 matches, 2 cards\n  Card 5: 0 matches, 1 card"},
+	}
+
+	for _, test := range tests {
+		answer, err := s.Query(test.query)
+
+		if err != nil {
+			t.Errorf("Query(%q) error = %v", test.query, err)
+			continue
+		}
+
+		if answer.String() != test.want {
+			t.Errorf("Query(%q) = %q, want %q", test.query, answer, test.want)
+		}
+	}
+
+	if _, err := s.Query("top -1"); err == nil {
+		t.Errorf("Query(\"top -1\") succeeded")
+	}
+
+	if _, err := s.Query("tree 1 -1"); err == nil {
+		t.Errorf("Query(\"tree 1 -1\") succeeded")
+	}
+
+	if _, err := day04.Analyze(s.Cards(), map[int]int{9: 1}); err == nil {
+		t.Errorf("Analyze with an override for a missing card succeeded")
+	}
+}
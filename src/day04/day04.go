@@ -1,189 +1,280 @@
-package main
+package day04
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
+	"math"
+	"math/big"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
 type Card struct {
 	Id             int
 	WinningNumbers []int
 	OwnNumbers     []int
+	MatchedNumbers []int
 	WinningCount   int
-	Points         int
+	// Points doubles with every match past the first, so cards with many
+	// matches score beyond any fixed size integer.
+	Points *big.Int
 }
 
 type CardStack struct {
-	Card     Card
-	Quantity int
+	Card Card
+	// Quantity grows exponentially with the matches of the cards before it.
+	Quantity *big.Int
+}
+
+// CardPool holds a stack per card, sorted by card ID.
+type CardPool []CardStack
+
+type Solver struct {
+	cards []Card
+}
+
+func init() {
+	solver.Register(4, func() solver.Solver { return &Solver{} })
 }
 
-type CardPool map[int]CardStack
+func (s *Solver) Parse(lines []string) error {
+	cards, err := parseLines(lines)
+
+	if err != nil {
+		return input.InDay(4, err)
+	}
+
+	s.cards = cards
+
+	return nil
+}
+
+// Cards returns the parsed cards in input order.
+func (s *Solver) Cards() []Card {
+	return s.cards
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return getTotalPoints(s.cards), nil
+}
+
+func (s *Solver) Stream(part int, r io.Reader, maxLineLength int) (solver.Answer, error) {
+	if part != 1 {
+		return nil, solver.ErrNotImplemented
+	}
+
+	sum := new(big.Int)
 
-func countCards(cardPool CardPool) int {
-	count := 0
+	err := input.EachLine(r, maxLineLength, func(lineNumber int, line string) error {
+		card, err := parseLine(line, lineNumber)
+
+		if err != nil {
+			return err
+		}
+
+		sum.Add(sum, card.Points)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, input.InDay(4, err)
+	}
+
+	return sum, nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return countCards(s.CardPool()), nil
+}
+
+// CardPool returns the stacks of every card after all the copies were won.
+func (s *Solver) CardPool() CardPool {
+	return processCardPool(createCardPool(s.cards))
+}
+
+func countCards(cardPool CardPool) *big.Int {
+	count := new(big.Int)
 
 	for _, cardStack := range cardPool {
-		count = count + cardStack.Quantity
+		count.Add(count, cardStack.Quantity)
 	}
 
 	return count
 }
 
+// processCardPool adds the copies every card wins to the cards after it.
+// Each card adds its quantity to a run of following cards, so the additions
+// are kept in a difference array and summed up as the cards are visited.
 func processCardPool(cardPool CardPool) CardPool {
-	maxIdCard := len(cardPool)
+	additions := make([]big.Int, len(cardPool)+1)
+	added := new(big.Int)
 
-	for idCard := 1; idCard <= maxIdCard; idCard = idCard + 1 {
-		startClonningId := idCard + 1
-		endClonningId := idCard + cardPool[idCard].Card.WinningCount
+	for index := range cardPool {
+		added.Add(added, &additions[index])
+		cardPool[index].Quantity.Add(cardPool[index].Quantity, added)
 
-		if startClonningId > maxIdCard {
-			break
+		end := cardPool.lastWon(index)
+
+		if end == index {
+			continue
 		}
 
-		for cloneIdCard := startClonningId; (cloneIdCard <= endClonningId) && (cloneIdCard <= maxIdCard); cloneIdCard = cloneIdCard + 1 {
-			if clonedCardStack, ok := cardPool[cloneIdCard]; ok {
+		additions[index+1].Add(&additions[index+1], cardPool[index].Quantity)
+		additions[end+1].Sub(&additions[end+1], cardPool[index].Quantity)
+	}
+
+	return cardPool
+}
 
-				cloneQuantity := cardPool[idCard].Quantity
-				clonedCardStack.Quantity = clonedCardStack.Quantity + cloneQuantity
+// lastWon returns the index of the last card a copy of the card at index
+// wins a copy of, or index when it wins none. Missing IDs win nothing, so
+// the card wins every following card whose ID is at most WinningCount
+// above its own.
+func (p CardPool) lastWon(index int) int {
+	card := p[index].Card
+	lastId := math.MaxInt
 
-				cardPool[cloneIdCard] = clonedCardStack
-			}
-		}
+	if card.Id <= math.MaxInt-card.WinningCount {
+		lastId = card.Id + card.WinningCount
 	}
 
-	return cardPool
+	following := p[index+1:]
+
+	return index + sort.Search(len(following), func(i int) bool { return following[i].Card.Id > lastId })
+}
+
+// index returns the index of the card with the given ID.
+func (p CardPool) index(id int) (int, bool) {
+	index := sort.Search(len(p), func(i int) bool { return p[i].Card.Id >= id })
+
+	return index, index < len(p) && p[index].Card.Id == id
 }
 
 func createCardPool(cards []Card) CardPool {
-	cardPool := make(CardPool)
+	cardPool := make(CardPool, len(cards))
 
-	for _, card := range cards {
-		cardPool[card.Id] = CardStack{Card: card, Quantity: 1}
+	for index, card := range cards {
+		cardPool[index] = CardStack{Card: card, Quantity: big.NewInt(1)}
 	}
 
+	sort.Slice(cardPool, func(i, j int) bool {
+		return cardPool[i].Card.Id < cardPool[j].Card.Id
+	})
+
 	return cardPool
 }
 
-func getTotalPoints(cards []Card) int {
-	sum := 0
+func getTotalPoints(cards []Card) *big.Int {
+	sum := new(big.Int)
 
 	for _, card := range cards {
-		sum = sum + card.Points
+		sum.Add(sum, card.Points)
 	}
 
 	return sum
 }
 
-func countCardPoints(winningNumbers []int, ownNumbers []int) (int, int) {
-	count := 0
-	points := 0
+// matchNumbers returns the winning numbers found among ownNumbers, in the
+// order they are listed.
+func matchNumbers(winningNumbers []int, ownNumbers []int) []int {
+	var own numberSet
+
+	for _, ownNumber := range ownNumbers {
+		own.add(ownNumber)
+	}
+
+	var matched []int
 
 	for _, winningNumber := range winningNumbers {
-		for _, ownNumber := range ownNumbers {
-			if winningNumber == ownNumber {
-				if points == 0 {
-					points = 1
-				} else {
-					points = points + points
-				}
-
-				count = count + 1
-
-				break
-			}
+		if own.contains(winningNumber) {
+			matched = append(matched, winningNumber)
 		}
 	}
 
-	return count, points
+	return matched
 }
 
-func fieldsStringToNumber(fields []string) []int {
-	var intFields []int
-
-	for _, field := range fields {
-		intField, _ := strconv.Atoi(field)
-		intFields = append(intFields, intField)
+func getCardPoints(winningCount int) *big.Int {
+	if winningCount == 0 {
+		return new(big.Int)
 	}
 
-	return intFields
+	return new(big.Int).Lsh(big.NewInt(1), uint(winningCount-1))
 }
 
-func parseLine(line string) Card {
-	head, numbersString, _ := strings.Cut(line, ":")
-	winningString, ownString, _ := strings.Cut(numbersString, "|")
+func parseLine(line string, lineNumber int) (Card, error) {
+	head, numbersString, found := strings.Cut(line, ":")
 
-	headFields := strings.Fields(head)
-
-	cardId, _ := strconv.Atoi(headFields[1])
-	winningNumbers := fieldsStringToNumber(strings.Fields(winningString))
-	ownNumbers := fieldsStringToNumber(strings.Fields(ownString))
+	if !found {
+		return Card{}, input.Errorf(lineNumber, 1, line, "missing \":\" after the card")
+	}
 
-	count, points := countCardPoints(winningNumbers, ownNumbers)
+	winningString, ownString, found := strings.Cut(numbersString, "|")
 
-	return Card{
-		Id:             cardId,
-		WinningNumbers: winningNumbers,
-		OwnNumbers:     ownNumbers,
-		WinningCount:   count,
-		Points:         points,
+	if !found {
+		return Card{}, input.Errorf(lineNumber, len(head)+2, numbersString, "missing \"|\" between winning and own numbers")
 	}
-}
 
-func parseLines(lines []string) []Card {
-	var cards []Card
+	headFields := input.SplitFields(head)
 
-	for _, line := range lines {
-		cards = append(cards, parseLine(line))
+	if len(headFields) != 2 || headFields[0].Text != "Card" {
+		return Card{}, input.Errorf(lineNumber, 1, head, "expected \"Card <id>\"")
 	}
 
-	return cards
-}
+	cardId, err := strconv.Atoi(headFields[1].Text)
+
+	if err != nil || cardId < 1 {
+		return Card{}, input.Errorf(lineNumber, headFields[1].Column, headFields[1].Text, "invalid card id")
+	}
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/day04/input.txt")
+	winningNumbers, err := input.ParseNumbers(winningString)
 
 	if err != nil {
-		log.Fatal("Could not open the input file")
+		return Card{}, input.Locate(err, lineNumber, len(head)+1)
 	}
 
-	cards := parseLines(lines)
+	ownNumbers, err := input.ParseNumbers(ownString)
 
-	sum := getTotalPoints(cards)
-	fmt.Println("Sum:", sum)
-
-	cardPool := createCardPool(cards)
-	cardPool = processCardPool(cardPool)
-	cardsCount := countCards(cardPool)
+	if err != nil {
+		return Card{}, input.Locate(err, lineNumber, len(head)+1+len(winningString)+1)
+	}
 
-	fmt.Println("Cards count:", cardsCount)
+	matchedNumbers := matchNumbers(winningNumbers, ownNumbers)
 
+	return Card{
+		Id:             cardId,
+		WinningNumbers: winningNumbers,
+		OwnNumbers:     ownNumbers,
+		MatchedNumbers: matchedNumbers,
+		WinningCount:   len(matchedNumbers),
+		Points:         getCardPoints(len(matchedNumbers)),
+	}, nil
 }
 
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+func parseLines(lines []string) ([]Card, error) {
+	var cards []Card
 
-	if err != nil {
-		return nil, err
-	}
+	seen := make(map[int]bool)
 
-	defer file.Close()
+	for index, line := range lines {
+		card, err := parseLine(line, index+1)
 
-	scanner := bufio.NewScanner(file)
+		if err != nil {
+			return nil, err
+		}
 
-	var lines []string
+		if seen[card.Id] {
+			return nil, input.Errorf(index+1, 0, line, "second card %d", card.Id)
+		}
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
+		seen[card.Id] = true
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+		cards = append(cards, card)
 	}
 
-	return lines, nil
+	return cards, nil
 }
@@ -0,0 +1,189 @@
+package day04_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day04"
+)
+
+func TestMatches(t *testing.T) {
+	s := &day04.Solver{}
+
+	err := s.Parse([]string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 100000 -3 7 | 7 -3 100000 99999",
+		"Card 3: 1 2 3 | 4 5 6",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		matched []int
+		points  int
+	}{
+		{[]int{48, 83, 86, 17}, 8},
+		{[]int{100000, -3, 7}, 4},
+		{nil, 0},
+	}
+
+	for i, test := range tests {
+		card := s.Cards()[i]
+
+		if !reflect.DeepEqual(card.MatchedNumbers, test.matched) || card.WinningCount != len(test.matched) || card.Points.Int64() != int64(test.points) {
+			t.Errorf("card %d matched %v for %d points, want %v for %d points",
+				card.Id, card.MatchedNumbers, card.Points, test.matched, test.points)
+		}
+	}
+}
+
+func TestManyMatches(t *testing.T) {
+	var lines []string
+
+	for i, count := range []int{64, 100} {
+		numbers := make([]string, count)
+
+		for number := range numbers {
+			numbers[number] = fmt.Sprint(number + 1)
+		}
+
+		line := strings.Join(numbers, " ")
+		lines = append(lines, fmt.Sprintf("Card %d: %s | %s", i+1, line, line))
+	}
+
+	s := &day04.Solver{}
+
+	if err := s.Parse(lines); err != nil {
+		t.Fatal(err)
+	}
+
+	if points := s.Cards()[0].Points.String(); points != "9223372036854775808" {
+		t.Errorf("a card with 64 matches scored %s, want 2^63", points)
+	}
+
+	answer, err := s.Part1()
+
+	if err != nil || answer.String() != "633825300123338072785206378496" {
+		t.Errorf("Part1() = %v, %v, want 2^63 + 2^99", answer, err)
+	}
+
+	streamed, err := s.Stream(1, strings.NewReader(strings.Join(lines, "\n")), 0)
+
+	if err != nil || streamed.String() != answer.String() {
+		t.Errorf("Stream(1) = %v, %v, want %v", streamed, err, answer)
+	}
+}
+
+func TestCascade(t *testing.T) {
+	s := &day04.Solver{}
+
+	err := s.Parse([]string{
+		"Card 3: 1 2 | 1 2",
+		"Card 1: 1 2 | 1 2",
+		"Card 6: 1 | 2",
+		"Card 2: 1 | 1",
+		"Card 4: 1 | 2",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int{1, 2, 3, 4, 6}
+	quantities := []int{1, 2, 4, 5, 1}
+	cardPool := s.CardPool()
+
+	if len(cardPool) != len(ids) {
+		t.Fatalf("card pool holds %d cards, want %d", len(cardPool), len(ids))
+	}
+
+	for i, cardStack := range cardPool {
+		if cardStack.Card.Id != ids[i] || cardStack.Quantity.Int64() != int64(quantities[i]) {
+			t.Errorf("stack %d holds %d copies of card %d, want %d copies of card %d",
+				i, cardStack.Quantity, cardStack.Card.Id, quantities[i], ids[i])
+		}
+	}
+
+	answer, err := s.Part2()
+
+	if err != nil || answer.String() != "13" {
+		t.Errorf("Part2() = %v, %v, want 13", answer, err)
+	}
+}
+
+func TestSparseIds(t *testing.T) {
+	s := &day04.Solver{}
+
+	err := s.Parse([]string{
+		"Card 99999999999: 1 | 1",
+		"Card 9223372036854775807: 1 2 | 1 2",
+		"Card 5: 1 | 1",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answer, err := s.Part2()
+
+	if err != nil || answer.String() != "3" {
+		t.Errorf("Part2() = %v, %v, want 3", answer, err)
+	}
+}
+
+func TestManyCopies(t *testing.T) {
+	numbers := make([]string, 70)
+
+	for number := range numbers {
+		numbers[number] = fmt.Sprint(number + 1)
+	}
+
+	line := strings.Join(numbers, " ")
+	lines := make([]string, 70)
+
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Card %d: %s | %s", i+1, line, line)
+	}
+
+	s := &day04.Solver{}
+
+	if err := s.Parse(lines); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every card wins a copy of all the cards after it, so card n ends up
+	// with 2^(n-1) copies and the pile with 2^70 - 1 cards.
+	const want = "1180591620717411303423"
+
+	if answer, err := s.Part2(); err != nil || answer.String() != want {
+		t.Errorf("Part2() = %v, %v, want %s", answer, err, want)
+	}
+
+	analysis, err := day04.Analyze(s.Cards(), nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A copy of card n adds itself and the 2^(70-n) - 1 cards it wins.
+	if top := analysis.Top(1); len(top) != 1 || top[0].Id != 1 || top[0].Cards.String() != "590295810358705651712" {
+		t.Errorf("Top(1) = %+v, want card 1 adding 2^69 cards", top)
+	}
+
+	var tree strings.Builder
+
+	if err := analysis.WriteCopyTree(&tree, 1, 70); err != nil {
+		t.Fatal(err)
+	}
+
+	treeLines := strings.Split(strings.TrimSuffix(tree.String(), "\n"), "\n")
+
+	if len(treeLines) != day04.MaxCopyTreeLines+1 || !strings.HasSuffix(treeLines[len(treeLines)-1], "... cut after 10000 cards") {
+		t.Errorf("copy tree of card 1 has %d lines ending with %q, want it cut after %d cards",
+			len(treeLines), treeLines[len(treeLines)-1], day04.MaxCopyTreeLines)
+	}
+}
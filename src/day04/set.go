@@ -0,0 +1,42 @@
+package day04
+
+// bitsetLimit bounds the numbers kept in the bitset of a numberSet, so a
+// single huge number cannot make it allocate a huge bitset.
+const bitsetLimit = 1 << 16
+
+// numberSet is a set of numbers. The small non-negative numbers scratchcards
+// use are kept in a bitset and any other number in a map.
+type numberSet struct {
+	bits   []uint64
+	others map[int]bool
+}
+
+func (s *numberSet) add(number int) {
+	if number < 0 || number >= bitsetLimit {
+		if s.others == nil {
+			s.others = make(map[int]bool)
+		}
+
+		s.others[number] = true
+
+		return
+	}
+
+	word := number / 64
+
+	for len(s.bits) <= word {
+		s.bits = append(s.bits, 0)
+	}
+
+	s.bits[word] = s.bits[word] | 1<<(number%64)
+}
+
+func (s *numberSet) contains(number int) bool {
+	if number < 0 || number >= bitsetLimit {
+		return s.others[number]
+	}
+
+	word := number / 64
+
+	return word < len(s.bits) && s.bits[word]&(1<<(number%64)) != 0
+}
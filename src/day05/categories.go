@@ -0,0 +1,153 @@
+package day05
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+// Map converts the codes of a source category into the codes of a
+// destination category.
+type Map struct {
+	Source      string
+	Destination string
+	// Mappings are sorted by Start. Codes no mapping covers keep their
+	// value.
+	Mappings []Mapping
+}
+
+// Map returns the map from source to destination.
+func (a Almanac) Map(source string, destination string) (*Map, bool) {
+	for _, categoryMap := range a.Maps[source] {
+		if categoryMap.Destination == destination {
+			return categoryMap, true
+		}
+	}
+
+	return nil, false
+}
+
+// Categories returns every category a map converts from or to, sorted.
+func (a Almanac) Categories() []string {
+	seen := make(map[string]bool)
+
+	var categories []string
+
+	for source, categoryMaps := range a.Maps {
+		for _, categoryMap := range categoryMaps {
+			for _, category := range []string{source, categoryMap.Destination} {
+				if !seen[category] {
+					seen[category] = true
+					categories = append(categories, category)
+				}
+			}
+		}
+	}
+
+	sort.Strings(categories)
+
+	return categories
+}
+
+// Path returns the shortest chain of maps converting source into target.
+func (a Almanac) Path(source string, target string) ([]*Map, error) {
+	if source == target {
+		return nil, nil
+	}
+
+	reachedBy := map[string]*Map{source: nil}
+	queue := []string{source}
+
+	for len(queue) > 0 {
+		category := queue[0]
+		queue = queue[1:]
+
+		for _, categoryMap := range a.Maps[category] {
+			if _, ok := reachedBy[categoryMap.Destination]; ok {
+				continue
+			}
+
+			reachedBy[categoryMap.Destination] = categoryMap
+
+			if categoryMap.Destination != target {
+				queue = append(queue, categoryMap.Destination)
+				continue
+			}
+
+			var path []*Map
+
+			for step := categoryMap; step != nil; step = reachedBy[step.Source] {
+				path = append([]*Map{step}, path...)
+			}
+
+			return path, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no maps lead from %s to %s", source, target)
+}
+
+// Resolve converts ranges of source codes into the target codes they map to.
+func (a Almanac) Resolve(source string, target string, ranges []Range) ([]Range, error) {
+	path, err := a.Path(source, target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, categoryMap := range path {
+		ranges = transformRanges(categoryMap.Mappings, ranges)
+	}
+
+	return mergeRanges(ranges), nil
+}
+
+// Convert returns the target code a single source code maps to.
+func (a Almanac) Convert(source string, target string, code int) (int, error) {
+	ranges, err := a.Resolve(source, target, []Range{{Start: code, End: code}})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return ranges[0].Start, nil
+}
+
+// Query converts codes between any two categories: "seed humidity 79 14"
+// returns the humidities of seeds 79 and 14.
+func (s *Solver) Query(query string) (solver.Answer, error) {
+	fields := strings.Fields(query)
+
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("invalid query %q, expected <source> <target> <code>...", query)
+	}
+
+	codes, err := input.ParseNumbers(strings.Join(fields[2:], " "))
+
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]string, len(codes))
+
+	for i, code := range codes {
+		target, err := s.almanac.Convert(fields[0], fields[1], code)
+
+		if err != nil {
+			return nil, err
+		}
+
+		converted[i] = fmt.Sprintf("%s %d: %s %d", fields[0], code, fields[1], target)
+	}
+
+	return codeList(strings.Join(converted, "\n")), nil
+}
+
+type codeList string
+
+func (l codeList) String() string {
+	return string(l)
+}
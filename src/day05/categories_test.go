@@ -0,0 +1,98 @@
+package day05_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day05"
+)
+
+const example = `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4`
+
+func TestCategories(t *testing.T) {
+	s := &day05.Solver{}
+
+	if err := s.Parse(strings.Split(example, "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	almanac := s.Almanac()
+
+	tests := []struct {
+		source string
+		target string
+		code   int
+		want   int
+	}{
+		{"seed", "soil", 79, 81},
+		{"seed", "humidity", 79, 78},
+		{"light", "location", 74, 82},
+		{"soil", "soil", 5, 5},
+	}
+
+	for _, test := range tests {
+		got, err := almanac.Convert(test.source, test.target, test.code)
+
+		if err != nil || got != test.want {
+			t.Errorf("Convert(%s, %s, %d) = %d, %v, want %d", test.source, test.target, test.code, got, err, test.want)
+		}
+	}
+
+	if _, err := almanac.Convert("location", "seed", 1); err == nil {
+		t.Errorf("Convert from location to seed succeeded without maps back")
+	}
+
+	if got := len(almanac.Categories()); got != 8 {
+		t.Errorf("len(Categories()) = %d, want 8", got)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	s := &day05.Solver{}
+
+	if err := s.Parse(strings.Split(example, "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	answer, err := s.Query("seed humidity 79 14")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "seed 79: humidity 78\nseed 14: humidity 43"; answer.String() != want {
+		t.Errorf("Query = %q, want %q", answer, want)
+	}
+}
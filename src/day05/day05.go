@@ -1,15 +1,14 @@
-package main
+package day05
 
 import (
-	"bufio"
 	"fmt"
-	"log"
 	"math"
-	"os"
+	"sort"
 	"strconv"
 	"strings"
-	"unicode"
-	"unicode/utf8"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
 type Mapping struct {
@@ -20,216 +19,295 @@ type Mapping struct {
 }
 
 type Almanac struct {
-	Seeds        map[int]Mapping
-	Soils        map[int]Mapping
-	Fertilizers  map[int]Mapping
-	Waters       map[int]Mapping
-	Lights       map[int]Mapping
-	Temperatures map[int]Mapping
-	Humidities   map[int]Mapping
-	Locations    map[int]Mapping
-}
-
-func createSeedsForSeedMapping(seedMappings map[int]Mapping, soilMappings map[int]Mapping) []int {
-	var seeds []int
-
-	for _, seedMapping := range seedMappings {
-		seeds = append(seeds, seedMapping.Start)
-		seeds = append(seeds, seedMapping.End)
-
-		for _, soilMapping := range soilMappings {
-			if soilMapping.Start > seedMapping.Start && soilMapping.Start < seedMapping.End {
-				seeds = append(seeds, soilMapping.Start)
-			}
+	Seeds     []int
+	seedsLine int
+	// Maps holds the maps from each source category, in input order.
+	Maps map[string][]*Map
+}
 
-			if soilMapping.End > seedMapping.Start && soilMapping.End < seedMapping.End {
-				seeds = append(seeds, soilMapping.End)
-			}
-		}
-	}
+// SeedMode says how the numbers of the seeds line are read.
+type SeedMode int
 
-	return seeds
+const (
+	// SingleSeeds reads every number as a seed, as part 1 does.
+	SingleSeeds SeedMode = 1
+	// SeedRanges reads the numbers as pairs of a first seed and a number
+	// of seeds, as part 2 does.
+	SeedRanges SeedMode = 2
+)
+
+// Range is the codes from Start to End, both included.
+type Range struct {
+	Start int
+	End   int
+}
+
+type Solver struct {
+	almanac Almanac
 }
 
-func getLowestLocationCode(lines []string) int {
+func init() {
+	solver.Register(5, func() solver.Solver { return &Solver{} })
+}
 
-	almanac := parseLines(lines)
+func (s *Solver) Parse(lines []string) error {
+	almanac, err := parseLines(lines)
 
-	lowestLocationCode := math.MaxInt
+	if err != nil {
+		return input.InDay(5, err)
+	}
+
+	s.almanac = almanac
+
+	return nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return s.LowestLocation(SingleSeeds)
+}
 
-	seeds := createSeedsForSeedMapping(almanac.Seeds, almanac.Soils)
+func (s *Solver) Part2() (solver.Answer, error) {
+	return s.LowestLocation(SeedRanges)
+}
 
-	var locations []int
+// Almanac returns the parsed almanac.
+func (s *Solver) Almanac() Almanac {
+	return s.almanac
+}
 
-	for _, seed := range seeds {
-		soil := getCode(almanac.Soils, seed)
-		fertilizer := getCode(almanac.Fertilizers, soil)
-		water := getCode(almanac.Waters, fertilizer)
-		light := getCode(almanac.Lights, water)
-		temperature := getCode(almanac.Temperatures, light)
-		humidity := getCode(almanac.Humidities, temperature)
-		location := getCode(almanac.Locations, humidity)
+// LowestLocation returns the lowest location of the seeds read in mode.
+func (s *Solver) LowestLocation(mode SeedMode) (solver.Answer, error) {
+	seeds, err := s.almanac.SeedRanges(mode)
 
-		locations = append(locations, location)
+	if err != nil {
+		return nil, input.InDay(5, err)
 	}
 
-	for _, location := range locations {
-		if location < lowestLocationCode {
-			lowestLocationCode = location
-		}
+	lowestLocationCode, err := getLowestLocationCode(s.almanac, seeds)
+
+	if err != nil {
+		return nil, input.InDay(5, err)
 	}
 
-	return lowestLocationCode
+	return solver.Int(lowestLocationCode), nil
 }
 
-func getCode(mappings map[int]Mapping, value int) int {
-	code := value
+// SeedRanges returns the seeds of the almanac read in mode.
+func (a Almanac) SeedRanges(mode SeedMode) ([]Range, error) {
+	var ranges []Range
+
+	switch mode {
+	case SingleSeeds:
+		for _, seed := range a.Seeds {
+			ranges = append(ranges, Range{Start: seed, End: seed})
+		}
+	case SeedRanges:
+		if len(a.Seeds)%2 != 0 {
+			return nil, input.Errorf(a.seedsLine, 0, "", "expected seed start/length pairs, found %d numbers", len(a.Seeds))
+		}
+
+		for index := 0; index < len(a.Seeds); index = index + 2 {
+			if a.Seeds[index+1] < 1 {
+				return nil, input.Errorf(a.seedsLine, 0, strconv.Itoa(a.Seeds[index+1]), "expected a seed range length of at least 1")
+			}
 
-	for _, mapping := range mappings {
-		if value >= mapping.Start && value <= mapping.End {
-			difference := value - mapping.Start
-			return mapping.Code + difference
+			ranges = append(ranges, Range{Start: a.Seeds[index], End: a.Seeds[index] + a.Seeds[index+1] - 1})
 		}
+	default:
+		return nil, fmt.Errorf("invalid seed mode %d", mode)
 	}
 
-	return code
+	return ranges, nil
 }
 
-func lineToMapping(line string) Mapping {
-	fields := fieldsStringToNumber(strings.Fields(line))
-	return Mapping{
-		Code:   fields[0],
-		Start:  fields[1],
-		End:    fields[1] + fields[2] - 1,
-		Length: fields[2],
+func getLowestLocationCode(almanac Almanac, ranges []Range) (int, error) {
+	locations, err := almanac.Resolve("seed", "location", ranges)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(locations) == 0 {
+		return 0, input.Errorf(almanac.seedsLine, 0, "", "no seeds")
+	}
+
+	lowestLocationCode := math.MaxInt
+
+	for _, location := range locations {
+		if location.Start < lowestLocationCode {
+			lowestLocationCode = location.Start
+		}
 	}
-}
 
-func seedLineToMapping(line string) map[int]Mapping {
-	fields := fieldsStringToNumber(strings.Fields(strings.TrimPrefix(line, "seeds:")))
+	return lowestLocationCode, nil
+}
 
-	seeds := make(map[int]Mapping)
+// transformRanges maps every code of ranges through the mappings of one map,
+// sorted by Start. Ranges are split where the mappings start and end: the
+// parts a mapping covers are shifted by it and the parts none covers keep
+// their codes. The result is sorted with overlapping and touching ranges
+// merged.
+func transformRanges(sortedMappings []Mapping, ranges []Range) []Range {
+	var transformed []Range
 
-	for index := 0; index < len(fields); index = index + 1 {
-		if index%2 != 0 {
+	for _, codeRange := range ranges {
+		if codeRange.Start > codeRange.End {
 			continue
 		}
 
-		seedStart := fields[index]
-		seedLength := fields[index+1]
-		seedEnd := seedStart + seedLength - 1
+		cursor := codeRange.Start
+
+		for _, mapping := range sortedMappings {
+			if mapping.End < cursor {
+				continue
+			}
+
+			if mapping.Start > codeRange.End {
+				break
+			}
+
+			if mapping.Start > cursor {
+				transformed = append(transformed, Range{Start: cursor, End: mapping.Start - 1})
+				cursor = mapping.Start
+			}
+
+			end := mapping.End
+
+			if codeRange.End < end {
+				end = codeRange.End
+			}
+
+			shift := mapping.Code - mapping.Start
+			transformed = append(transformed, Range{Start: cursor + shift, End: end + shift})
+			cursor = end + 1
+
+			if cursor > codeRange.End {
+				break
+			}
+		}
 
-		seeds[index] = Mapping{
-			Code:   index,
-			Start:  seedStart,
-			End:    seedEnd,
-			Length: seedLength,
+		if cursor <= codeRange.End {
+			transformed = append(transformed, Range{Start: cursor, End: codeRange.End})
 		}
 	}
 
-	return seeds
+	return mergeRanges(transformed)
 }
 
-func parseLines(lines []string) Almanac {
-	almanac := Almanac{
-		Seeds:        make(map[int]Mapping),
-		Soils:        make(map[int]Mapping),
-		Fertilizers:  make(map[int]Mapping),
-		Waters:       make(map[int]Mapping),
-		Lights:       make(map[int]Mapping),
-		Temperatures: make(map[int]Mapping),
-		Humidities:   make(map[int]Mapping),
-		Locations:    make(map[int]Mapping),
-	}
-
-	section := "seeds"
+func mergeRanges(ranges []Range) []Range {
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
 
-	for lineIndex, line := range lines {
+	var merged []Range
 
-		if line == "" {
-			continue
-		}
+	for _, codeRange := range ranges {
+		last := len(merged) - 1
 
-		firstRune, _ := utf8.DecodeRuneInString(line)
+		if last >= 0 && codeRange.Start <= merged[last].End+1 {
+			if codeRange.End > merged[last].End {
+				merged[last].End = codeRange.End
+			}
 
-		if lineIndex != 0 && unicode.IsLetter(firstRune) {
-			section = strings.Fields(line)[0]
 			continue
 		}
 
-		switch section {
-		case "seeds":
-			almanac.Seeds = seedLineToMapping(line)
-		case "seed-to-soil":
-			mapping := lineToMapping(line)
-			almanac.Soils[mapping.Code] = mapping
-		case "soil-to-fertilizer":
-			mapping := lineToMapping(line)
-			almanac.Fertilizers[mapping.Code] = mapping
-		case "fertilizer-to-water":
-			mapping := lineToMapping(line)
-			almanac.Waters[mapping.Code] = mapping
-		case "water-to-light":
-			mapping := lineToMapping(line)
-			almanac.Lights[mapping.Code] = mapping
-		case "light-to-temperature":
-			mapping := lineToMapping(line)
-			almanac.Temperatures[mapping.Code] = mapping
-		case "temperature-to-humidity":
-			mapping := lineToMapping(line)
-			almanac.Humidities[mapping.Code] = mapping
-		case "humidity-to-location":
-			mapping := lineToMapping(line)
-			almanac.Locations[mapping.Code] = mapping
-		}
+		merged = append(merged, codeRange)
 	}
 
-	return almanac
+	return merged
 }
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/test.txt")
+func lineToMapping(line string, lineNumber int) (Mapping, error) {
+	fields, err := input.ParseNumbers(line)
 
 	if err != nil {
-		log.Fatal("Could not open the input file")
+		return Mapping{}, input.Locate(err, lineNumber, 0)
+	}
+
+	if len(fields) != 3 {
+		return Mapping{}, input.Errorf(lineNumber, 1, line, "expected 3 numbers, found %d", len(fields))
 	}
 
-	location := getLowestLocationCode(lines)
+	if fields[2] < 1 {
+		length := input.SplitFields(line)[2]
+		return Mapping{}, input.Errorf(lineNumber, length.Column, length.Text, "expected a range length of at least 1")
+	}
 
-	fmt.Println("Location:", location)
+	return Mapping{
+		Code:   fields[0],
+		Start:  fields[1],
+		End:    fields[1] + fields[2] - 1,
+		Length: fields[2],
+	}, nil
 }
 
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
+func seedLineToNumbers(line string, lineNumber int) ([]int, error) {
+	if !strings.HasPrefix(line, "seeds:") {
+		return nil, input.Errorf(lineNumber, 1, line, "expected \"seeds:\"")
+	}
+
+	seeds, err := input.ParseNumbers(strings.TrimPrefix(line, "seeds:"))
 
 	if err != nil {
-		return nil, err
+		return nil, input.Locate(err, lineNumber, len("seeds:"))
 	}
 
-	defer file.Close()
+	return seeds, nil
+}
 
-	scanner := bufio.NewScanner(file)
+func parseLines(lines []string) (Almanac, error) {
+	almanac := Almanac{Maps: make(map[string][]*Map)}
 
-	var lines []string
+	sections := input.SplitSections(lines)
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	if len(sections) == 0 {
+		return almanac, input.Errorf(1, 0, "", "missing seeds line")
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if len(sections[0].Lines) != 1 {
+		return almanac, input.Errorf(sections[0].Line+1, 1, sections[0].Lines[1], "expected a blank line after the seeds")
 	}
 
-	return lines, nil
-}
+	seeds, err := seedLineToNumbers(sections[0].Lines[0], sections[0].Line)
+
+	if err != nil {
+		return almanac, err
+	}
+
+	almanac.Seeds = seeds
+	almanac.seedsLine = sections[0].Line
+
+	for _, section := range sections[1:] {
+		name, found := strings.CutSuffix(section.Lines[0], " map:")
+		source, destination, foundTo := strings.Cut(name, "-to-")
+
+		if !found || !foundTo || source == "" || destination == "" {
+			return almanac, input.Errorf(section.Line, 1, name, "expected a \"<source>-to-<destination> map:\" header")
+		}
+
+		if _, ok := almanac.Map(source, destination); ok {
+			return almanac, input.Errorf(section.Line, 1, name, "second %s map", name)
+		}
+
+		categoryMap := &Map{Source: source, Destination: destination}
+
+		for index, line := range section.Lines[1:] {
+			mapping, err := lineToMapping(line, section.Line+index+1)
+
+			if err != nil {
+				return almanac, err
+			}
+
+			categoryMap.Mappings = append(categoryMap.Mappings, mapping)
+		}
 
-func fieldsStringToNumber(fields []string) []int {
-	var intFields []int
+		sort.SliceStable(categoryMap.Mappings, func(i, j int) bool {
+			return categoryMap.Mappings[i].Start < categoryMap.Mappings[j].Start
+		})
 
-	for _, field := range fields {
-		intField, _ := strconv.Atoi(field)
-		intFields = append(intFields, intField)
+		almanac.Maps[source] = append(almanac.Maps[source], categoryMap)
 	}
 
-	return intFields
+	return almanac, nil
 }
@@ -0,0 +1,158 @@
+package day05_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day05"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+var almanacStages = []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+
+type almanacRange struct {
+	destination int
+	source      int
+	length      int
+}
+
+// randomAlmanac returns the lines of a small almanac with ranges whose
+// sources do not overlap within a map, and the lowest location of its seed
+// ranges found by mapping every single seed.
+func randomAlmanac(random *rand.Rand) ([]string, int) {
+	var seeds []int
+
+	seedsLine := "seeds:"
+
+	seedRanges := 1 + random.Intn(3)
+
+	for i := 0; i < seedRanges; i = i + 1 {
+		start := random.Intn(100)
+		length := 1 + random.Intn(30)
+		seedsLine = seedsLine + fmt.Sprintf(" %d %d", start, length)
+
+		for seed := start; seed < start+length; seed = seed + 1 {
+			seeds = append(seeds, seed)
+		}
+	}
+
+	lines := []string{seedsLine}
+
+	var stages [][]almanacRange
+
+	for i := 0; i+1 < len(almanacStages); i = i + 1 {
+		lines = append(lines, "", fmt.Sprintf("%s-to-%s map:", almanacStages[i], almanacStages[i+1]))
+
+		var ranges []almanacRange
+
+		source := random.Intn(10)
+		count := random.Intn(4)
+
+		for j := 0; j < count; j = j + 1 {
+			r := almanacRange{destination: random.Intn(150), source: source, length: 1 + random.Intn(40)}
+			ranges = append(ranges, r)
+			lines = append(lines, fmt.Sprintf("%d %d %d", r.destination, r.source, r.length))
+			source = source + r.length + random.Intn(10)
+		}
+
+		stages = append(stages, ranges)
+	}
+
+	lowest := -1
+
+	for _, seed := range seeds {
+		code := seed
+
+		for _, ranges := range stages {
+			for _, r := range ranges {
+				if code >= r.source && code < r.source+r.length {
+					code = r.destination + code - r.source
+					break
+				}
+			}
+		}
+
+		if lowest == -1 || code < lowest {
+			lowest = code
+		}
+	}
+
+	return lines, lowest
+}
+
+func TestSeedRanges(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 200; i = i + 1 {
+		lines, want := randomAlmanac(random)
+
+		s := &day05.Solver{}
+
+		if err := s.Parse(lines); err != nil {
+			t.Fatal(err)
+		}
+
+		answer, err := s.Part2()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if answer.String() != fmt.Sprint(want) {
+			t.Fatalf("Part2() = %s, want %d for\n%s", answer, want, strings.Join(lines, "\n"))
+		}
+	}
+}
+
+func TestSeedModes(t *testing.T) {
+	s := &day05.Solver{}
+
+	if err := s.Parse([]string{"seeds: 79 14 55", "", "seed-to-location map:", "50 98 2", "52 50 48"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if answer, err := s.Part1(); err != nil || answer.String() != "14" {
+		t.Errorf("Part1() = %v, %v, want 14", answer, err)
+	}
+
+	var parseError *input.ParseError
+
+	if _, err := s.Part2(); !errors.As(err, &parseError) || parseError.Line != 1 || parseError.Day != 5 {
+		t.Errorf("Part2() error = %v, want a *input.ParseError on day 5, line 1", err)
+	}
+}
+
+func TestEmptySeeds(t *testing.T) {
+	tests := []struct {
+		seeds string
+		part  int
+	}{
+		{"seeds: 79 0", 2},
+		{"seeds: 79 14 55 -1", 2},
+		{"seeds:", 1},
+		{"seeds:", 2},
+	}
+
+	for _, test := range tests {
+		s := &day05.Solver{}
+
+		if err := s.Parse([]string{test.seeds, "", "seed-to-location map:", "50 98 2"}); err != nil {
+			t.Fatal(err)
+		}
+
+		solve := s.Part1
+
+		if test.part == 2 {
+			solve = s.Part2
+		}
+
+		var parseError *input.ParseError
+
+		if answer, err := solve(); !errors.As(err, &parseError) || parseError.Line != 1 {
+			t.Errorf("%q: Part%d() = %v, %v, want a *input.ParseError on line 1", test.seeds, test.part, answer, err)
+		}
+	}
+}
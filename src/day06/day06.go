@@ -0,0 +1,162 @@
+package day06
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+type Race struct {
+	Time   int
+	Record int
+}
+
+type Solver struct {
+	races       []Race
+	joinedRaces []Race
+}
+
+func init() {
+	solver.Register(6, func() solver.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(lines []string) error {
+	races, err := parseLines(lines)
+
+	if err != nil {
+		return input.InDay(6, err)
+	}
+
+	joinedRaces, err := parseLinesPart2(lines)
+
+	if err != nil {
+		return input.InDay(6, err)
+	}
+
+	s.races = races
+	s.joinedRaces = joinedRaces
+
+	return nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Int(multiplyRacePossibilities(s.races)), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Int(multiplyRacePossibilities(s.joinedRaces)), nil
+}
+
+func multiplyRacePossibilities(races []Race) int {
+	product := 1
+
+	for _, race := range races {
+		possibilities := calculateRacePossibilities(race)
+		product = product * possibilities
+	}
+
+	return product
+}
+
+func calculateRacePossibilities(race Race) int {
+	possibilities, holdTime, distance := 0, 0, 0
+
+	for distance >= 0 {
+		distance = (holdTime * race.Time) - (holdTime * holdTime)
+
+		if distance > race.Record {
+			possibilities = possibilities + 1
+		}
+
+		holdTime = holdTime + 1
+	}
+
+	return possibilities
+}
+
+func parseRaceLine(line string, lineNumber int, prefix string) ([]int, error) {
+	if !strings.HasPrefix(line, prefix) {
+		return nil, input.Errorf(lineNumber, 1, line, "expected %q", prefix)
+	}
+
+	numbers, err := input.ParseNumbers(strings.TrimPrefix(line, prefix))
+
+	if err != nil {
+		return nil, input.Locate(err, lineNumber, len(prefix))
+	}
+
+	return numbers, nil
+}
+
+func parseJoinedRaceLine(line string, lineNumber int, prefix string) (int, error) {
+	if _, err := parseRaceLine(line, lineNumber, prefix); err != nil {
+		return 0, err
+	}
+
+	joined := strings.Join(strings.Fields(strings.TrimPrefix(line, prefix)), "")
+	number, err := strconv.Atoi(joined)
+
+	if err != nil {
+		return 0, input.Errorf(lineNumber, len(prefix)+1, joined, "invalid joined number")
+	}
+
+	return number, nil
+}
+
+func parseLines(lines []string) ([]Race, error) {
+	var races []Race
+
+	if len(lines) != 2 {
+		return nil, input.Errorf(len(lines), 0, "", "expected time and distance lines, found %d lines", len(lines))
+	}
+
+	times, err := parseRaceLine(lines[0], 1, "Time:")
+
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := parseRaceLine(lines[1], 2, "Distance:")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(times) != len(records) {
+		return nil, input.Errorf(2, 1, lines[1], "found %d times but %d distances", len(times), len(records))
+	}
+
+	for i, time := range times {
+		race := Race{Time: time, Record: records[i]}
+		races = append(races, race)
+	}
+
+	return races, nil
+}
+
+func parseLinesPart2(lines []string) ([]Race, error) {
+	var races []Race
+
+	if len(lines) != 2 {
+		return nil, input.Errorf(len(lines), 0, "", "expected time and distance lines, found %d lines", len(lines))
+	}
+
+	time, err := parseJoinedRaceLine(lines[0], 1, "Time:")
+
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := parseJoinedRaceLine(lines[1], 2, "Distance:")
+
+	if err != nil {
+		return nil, err
+	}
+
+	race := Race{Time: time, Record: record}
+	races = append(races, race)
+
+	return races, nil
+}
@@ -1,14 +1,14 @@
-package main
+package day07
 
 import (
-	"bufio"
 	"fmt"
-	"log"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
 	"unicode"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
 type HandType int
@@ -31,45 +31,45 @@ type Hand struct {
 	Bid      int
 }
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/input.txt")
-
-	if err != nil {
-		log.Fatal("Could not open the input file")
-	}
+type Solver struct {
+	hands      []Hand
+	jokerHands []Hand
+}
 
-	winnings := part1(lines)
-	fmt.Println("Part 1:", winnings)
-	winnings2 := part2(lines)
-	fmt.Println("Part 2:", winnings2)
+func init() {
+	solver.Register(7, func() solver.Solver { return &Solver{} })
 }
 
-func part1(lines []string) int {
-	var hands []Hand
+func (s *Solver) Parse(lines []string) error {
+	for index, line := range lines {
+		hand, err := parseLine(line, index+1)
 
-	for _, line := range lines {
-		hand := parseLine(line)
-		hands = append(hands, hand)
-	}
+		if err != nil {
+			return input.InDay(7, err)
+		}
 
-	sort.Slice(hands, func(i, j int) bool { return hands[i].Strength < hands[j].Strength })
+		jokerHand, err := parseLineWithJoker(line, index+1)
 
-	winnings := 0
-	for index, hands := range hands {
-		winnings = winnings + hands.Bid*(index+1)
+		if err != nil {
+			return input.InDay(7, err)
+		}
+
+		s.hands = append(s.hands, hand)
+		s.jokerHands = append(s.jokerHands, jokerHand)
 	}
 
-	return winnings
+	return nil
 }
 
-func part2(lines []string) int {
-	var hands []Hand
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Int(getTotalWinnings(s.hands)), nil
+}
 
-	for _, line := range lines {
-		hand := parseLineWithJoker(line)
-		hands = append(hands, hand)
-	}
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Int(getTotalWinnings(s.jokerHands)), nil
+}
 
+func getTotalWinnings(hands []Hand) int {
 	sort.Slice(hands, func(i, j int) bool { return hands[i].Strength < hands[j].Strength })
 
 	winnings := 0
@@ -80,15 +80,17 @@ func part2(lines []string) int {
 	return winnings
 }
 
-func parseLineWithJoker(line string) Hand {
+func parseLineWithJoker(line string, lineNumber int) (Hand, error) {
 	hand := Hand{
 		Labels: make(map[rune]int),
 	}
 
-	cardsString, bidString, _ := strings.Cut(line, " ")
-	fmt.Println(cardsString)
+	cardsString, bid, err := splitHand(line, lineNumber)
+
+	if err != nil {
+		return Hand{}, err
+	}
 
-	bid, _ := strconv.Atoi(bidString)
 	hand.Bid = bid
 
 	for _, card := range cardsString {
@@ -108,17 +110,48 @@ func parseLineWithJoker(line string) Hand {
 	strength := getHandStrengthWithJoker(hand)
 	hand.Strength = strength
 
-	return hand
+	return hand, nil
 }
 
-func parseLine(line string) Hand {
+const cardLabels = "23456789TJQKA"
+
+func splitHand(line string, lineNumber int) (string, int, error) {
+	cardsString, bidString, found := strings.Cut(line, " ")
+
+	if !found {
+		return "", 0, input.Errorf(lineNumber, 1, line, "expected cards and bid separated by a space")
+	}
+
+	if len(cardsString) != 5 {
+		return "", 0, input.Errorf(lineNumber, 1, cardsString, "expected 5 cards")
+	}
+
+	for index, card := range cardsString {
+		if !strings.ContainsRune(cardLabels, card) {
+			return "", 0, input.Errorf(lineNumber, index+1, string(card), "invalid card label")
+		}
+	}
+
+	bid, err := strconv.Atoi(strings.TrimSpace(bidString))
+
+	if err != nil {
+		return "", 0, input.Errorf(lineNumber, len(cardsString)+2, bidString, "invalid bid")
+	}
+
+	return cardsString, bid, nil
+}
+
+func parseLine(line string, lineNumber int) (Hand, error) {
 	hand := Hand{
 		Labels: make(map[rune]int),
 	}
 
-	cardsString, bidString, _ := strings.Cut(line, " ")
+	cardsString, bid, err := splitHand(line, lineNumber)
+
+	if err != nil {
+		return Hand{}, err
+	}
 
-	bid, _ := strconv.Atoi(bidString)
 	hand.Bid = bid
 
 	for _, card := range cardsString {
@@ -137,7 +170,7 @@ func parseLine(line string) Hand {
 	strength := getHandStrength(hand)
 	hand.Strength = strength
 
-	return hand
+	return hand, nil
 }
 
 func morthJoker(labels map[rune]int) map[rune]int {
@@ -183,8 +216,6 @@ func getCardStrengthWithJoker(card rune) int {
 		return 1
 	case 'T':
 		return 10
-	default:
-		log.Fatal("Invalid card label")
 	}
 
 	return 0
@@ -207,8 +238,6 @@ func getCardStrength(card rune) int {
 		return 11
 	case 'T':
 		return 10
-	default:
-		log.Fatal("Invalid card label")
 	}
 
 	return 0
@@ -255,8 +284,6 @@ func getHandType(labels map[rune]int) HandType {
 			pairCount = pairCount + 1
 		case 1:
 			singleCount = singleCount + 1
-		default:
-			log.Fatal("Invalid label count")
 		}
 
 		if trioCount == 1 && pairCount == 1 {
@@ -272,27 +299,3 @@ func getHandType(labels map[rune]int) HandType {
 
 	return HighCard
 }
-
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-
-	var lines []string
-
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return lines, nil
-}
@@ -0,0 +1,282 @@
+package day08
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+type Directions struct {
+	Left  string
+	Right string
+}
+
+type Locations map[string]Directions
+
+type Solver struct {
+	instructions []string
+	locations    Locations
+}
+
+func init() {
+	solver.Register(8, func() solver.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(lines []string) error {
+	instructions, locations, err := parseLines(lines)
+
+	if err != nil {
+		return input.InDay(8, err)
+	}
+
+	s.instructions = instructions
+	s.locations = locations
+
+	return nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	for _, node := range []string{"AAA", "ZZZ"} {
+		if _, ok := s.locations[node]; !ok {
+			return nil, errors.New("missing node " + node)
+		}
+	}
+
+	steps, err := countSteps(s.instructions, s.locations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return solver.Int(steps), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	steps, err := countGhostSteps(s.instructions, s.locations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if steps == nil {
+		return nil, errors.New("no node ends in A")
+	}
+
+	return steps, nil
+}
+
+// state is where a walk is: the node it stands on and the instruction it
+// follows next. A walk that comes back to a state repeats itself forever.
+type state struct {
+	location         string
+	instructionIndex int
+}
+
+func (s state) next(instructions []string, locations Locations) state {
+	location := locations[s.location].Right
+
+	if instructions[s.instructionIndex] == "L" {
+		location = locations[s.location].Left
+	}
+
+	return state{location: location, instructionIndex: (s.instructionIndex + 1) % len(instructions)}
+}
+
+func countSteps(instructions []string, locations Locations) (int, error) {
+	current := state{location: "AAA"}
+	visited := make(map[state]bool)
+
+	for steps := 0; ; steps = steps + 1 {
+		if current.location == "ZZZ" {
+			return steps, nil
+		}
+
+		if visited[current] {
+			return 0, errors.New("ZZZ cannot be reached from AAA")
+		}
+
+		visited[current] = true
+		current = current.next(instructions, locations)
+	}
+}
+
+func countGhostSteps(instructions []string, locations Locations) (*big.Int, error) {
+	var currentLocations []string
+	for location := range locations {
+		if lastRune, _ := utf8.DecodeLastRuneInString(location); lastRune == 'A' {
+			currentLocations = append(currentLocations, location)
+		}
+	}
+
+	loopSteps := make([]*big.Int, len(currentLocations))
+
+	for index, currentLocation := range currentLocations {
+		steps, err := findLoopSteps(currentLocation, instructions, locations)
+
+		if err != nil {
+			return nil, err
+		}
+
+		loopSteps[index] = new(big.Int).SetInt64(int64(steps))
+	}
+
+	minSteps := findLCMOfArray(loopSteps)
+
+	return minSteps, nil
+}
+
+func findLCMOfArray(numbers []*big.Int) *big.Int {
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	lcm := new(big.Int).Set(numbers[0])
+
+	for _, num := range numbers[1:] {
+		lcm = findLCM(lcm, num)
+	}
+
+	return lcm
+}
+
+func findLCM(a, b *big.Int) *big.Int {
+	// Use GCD to find LCM: LCM(a, b) = |a * b| / GCD(a, b)
+	var gcd big.Int
+	gcd.GCD(nil, nil, a, b)
+
+	lcm := new(big.Int).Abs(a)
+	lcm.Mul(lcm, b)
+	lcm.Div(lcm, &gcd)
+
+	return lcm
+}
+
+// findLoopSteps walks from startLocation until it comes back to a state it
+// was in, and returns how many steps apart the nodes ending in Z are on the
+// loop it ended up in. It fails when the loop has no such node or has them
+// at uneven distances, as no single period describes the walk then.
+func findLoopSteps(startLocation string, instructions []string, locations Locations) (int, error) {
+	current := state{location: startLocation}
+	visited := make(map[state]int)
+
+	var zSteps []int
+
+	steps := 0
+
+	for {
+		if _, ok := visited[current]; ok {
+			break
+		}
+
+		visited[current] = steps
+
+		if lastRune, _ := utf8.DecodeLastRuneInString(current.location); lastRune == 'Z' {
+			zSteps = append(zSteps, steps)
+		}
+
+		current = current.next(instructions, locations)
+		steps = steps + 1
+	}
+
+	loopStart := visited[current]
+	loopLength := steps - loopStart
+
+	// The nodes ending in Z on the loop, one lap later, follow the ones
+	// of the first lap.
+	var loopZSteps []int
+
+	for _, zStep := range zSteps {
+		if zStep >= loopStart {
+			loopZSteps = append(loopZSteps, zStep)
+		}
+	}
+
+	if len(loopZSteps) == 0 {
+		return 0, errors.New("no node ending in Z can be reached from " + startLocation)
+	}
+
+	loopZSteps = append(loopZSteps, loopZSteps[0]+loopLength)
+	loopSteps := loopZSteps[1] - loopZSteps[0]
+
+	for i := 2; i < len(loopZSteps); i = i + 1 {
+		if loopZSteps[i]-loopZSteps[i-1] != loopSteps {
+			return 0, errors.New("the nodes ending in Z reached from " + startLocation + " are not evenly spaced")
+		}
+	}
+
+	return loopSteps, nil
+}
+
+func parseLines(lines []string) ([]string, Locations, error) {
+	if len(lines) < 3 {
+		return nil, nil, input.Errorf(len(lines), 0, "", "expected instructions, a blank line and the network")
+	}
+
+	if lines[0] == "" {
+		return nil, nil, input.Errorf(1, 1, "", "missing instructions")
+	}
+
+	for index, instruction := range lines[0] {
+		if instruction != 'L' && instruction != 'R' {
+			return nil, nil, input.Errorf(1, index+1, string(instruction), "invalid instruction")
+		}
+	}
+
+	if lines[1] != "" {
+		return nil, nil, input.Errorf(2, 1, lines[1], "expected a blank line")
+	}
+
+	instructions := strings.Split(lines[0], "")
+
+	locations := make(Locations)
+	// defined holds the nodes in input order, so that the first unknown
+	// node reported is always the same one.
+	var defined []string
+	lineNumbers := make(map[string]int)
+
+	for index, line := range lines {
+		if index < 2 {
+			continue
+		}
+
+		location, directions, found := strings.Cut(line, "=")
+
+		if !found {
+			return nil, nil, input.Errorf(index+1, 1, line, "expected \"<node> = (<left>, <right>)\"")
+		}
+
+		fields := input.SplitFields(directions)
+
+		if len(fields) != 2 || !strings.HasPrefix(fields[0].Text, "(") || !strings.HasSuffix(fields[0].Text, ",") || !strings.HasSuffix(fields[1].Text, ")") {
+			return nil, nil, input.Errorf(index+1, len(location)+2, directions, "expected \"(<left>, <right>)\"")
+		}
+
+		location = strings.TrimSpace(location)
+		left := strings.Trim(fields[0].Text, "(,)")
+		right := strings.Trim(fields[1].Text, "(,)")
+
+		if _, ok := locations[location]; ok {
+			return nil, nil, input.Errorf(index+1, 1, location, "node defined twice")
+		}
+
+		locations[location] = Directions{Left: left, Right: right}
+		defined = append(defined, location)
+		lineNumbers[location] = index + 1
+	}
+
+	for _, location := range defined {
+		directions := locations[location]
+
+		for _, next := range []string{directions.Left, directions.Right} {
+			if _, ok := locations[next]; !ok {
+				return nil, nil, input.Errorf(lineNumbers[location], 0, next, "unknown node")
+			}
+		}
+	}
+
+	return instructions, locations, nil
+}
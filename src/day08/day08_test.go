@@ -0,0 +1,50 @@
+package day08_test
+
+import (
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/day08"
+)
+
+func TestPart2WithoutStartNodes(t *testing.T) {
+	s := &day08.Solver{}
+
+	if err := s.Parse([]string{"L", "", "BBB = (ZZZ, ZZZ)", "ZZZ = (ZZZ, ZZZ)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if answer, err := s.Part2(); err == nil {
+		t.Errorf("Part2() = %v, want an error for a map without nodes ending in A", answer)
+	}
+}
+
+func TestUnreachableEnd(t *testing.T) {
+	tests := []struct {
+		name  string
+		part  int
+		lines []string
+	}{
+		{"no ZZZ", 1, []string{"L", "", "AAA = (BBB, BBB)", "BBB = (AAA, AAA)"}},
+		{"ZZZ off the path", 1, []string{"LR", "", "AAA = (BBB, BBB)", "BBB = (AAA, AAA)", "ZZZ = (ZZZ, ZZZ)"}},
+		{"no Z on the loop", 2, []string{"L", "", "11A = (11Z, 11Z)", "11Z = (11B, 11B)", "11B = (11B, 11B)"}},
+		{"uneven Z on the loop", 2, []string{"L", "", "11A = (11Z, 11Z)", "11Z = (22Z, 22Z)", "22Z = (11B, 11B)", "11B = (11Z, 11Z)"}},
+	}
+
+	for _, test := range tests {
+		s := &day08.Solver{}
+
+		if err := s.Parse(test.lines); err != nil {
+			t.Fatal(err)
+		}
+
+		solve := s.Part1
+
+		if test.part == 2 {
+			solve = s.Part2
+		}
+
+		if answer, err := solve(); err == nil {
+			t.Errorf("%s: Part%d() = %v, want an error", test.name, test.part, answer)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package day09
+
+import (
+	"io"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+type Solver struct {
+	histories [][]int
+}
+
+func init() {
+	solver.Register(9, func() solver.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(lines []string) error {
+	for index, line := range lines {
+		values, err := parseHistory(line, index+1)
+
+		if err != nil {
+			return input.InDay(9, err)
+		}
+
+		s.histories = append(s.histories, values)
+	}
+
+	return nil
+}
+
+func (s *Solver) Part1() (solver.Answer, error) {
+	sum := 0
+
+	for _, values := range s.histories {
+		sum = sum + extrapolateForwards(values)
+	}
+
+	return solver.Int(sum), nil
+}
+
+func (s *Solver) Part2() (solver.Answer, error) {
+	sum := 0
+
+	for _, values := range s.histories {
+		sum = sum + extrapolateBackwards(values)
+	}
+
+	return solver.Int(sum), nil
+}
+
+func (s *Solver) Stream(part int, r io.Reader, maxLineLength int) (solver.Answer, error) {
+	var extrapolate func(values []int) int
+
+	switch part {
+	case 1:
+		extrapolate = extrapolateForwards
+	case 2:
+		extrapolate = extrapolateBackwards
+	default:
+		return nil, solver.ErrNotImplemented
+	}
+
+	sum := 0
+
+	err := input.EachLine(r, maxLineLength, func(lineNumber int, line string) error {
+		values, err := parseHistory(line, lineNumber)
+
+		if err != nil {
+			return err
+		}
+
+		sum = sum + extrapolate(values)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, input.InDay(9, err)
+	}
+
+	return solver.Int(sum), nil
+}
+
+func parseHistory(line string, lineNumber int) ([]int, error) {
+	values, err := input.ParseNumbers(line)
+
+	if err != nil {
+		return nil, input.Locate(err, lineNumber, 0)
+	}
+
+	if len(values) == 0 {
+		return nil, input.Errorf(lineNumber, 0, "", "expected a history of values")
+	}
+
+	return values, nil
+}
+
+func extrapolateForwards(values []int) int {
+	nextValues := predictNextValue(values)
+	return nextValues[len(nextValues)-1]
+}
+
+func extrapolateBackwards(values []int) int {
+	reversedValues := reverseSlice(values)
+	nextValues := predictNextValue(reversedValues)
+	return nextValues[len(nextValues)-1]
+}
+
+func reverseSlice(values []int) []int {
+	length := len(values)
+
+	reversedValues := make([]int, length)
+
+	for i := 0; i < length; i = i + 1 {
+		reversedValues[length-i-1] = values[i]
+	}
+
+	return reversedValues
+}
+
+func predictNextValue(values []int) []int {
+	isAllZeros := true
+
+	for i := 1; i < len(values); i = i + 1 {
+		if values[i] != 0 {
+			isAllZeros = false
+			break
+		}
+	}
+
+	if isAllZeros {
+		values = append(values, 0)
+		return values
+	}
+
+	var differences []int
+
+	for i := 1; i < len(values); i = i + 1 {
+		difference := values[i] - values[i-1]
+		differences = append(differences, difference)
+	}
+
+	nextValues := predictNextValue(differences)
+
+	nexValue := values[len(values)-1] + nextValues[len(nextValues)-1]
+	values = append(values, nexValue)
+
+	return values
+}
@@ -1,12 +1,12 @@
-package main
+package day10
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
 	"strings"
 	"unicode/utf8"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/grid"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
 )
 
 type Direction string
@@ -80,35 +80,43 @@ const (
 // 	Connections: []Direction{South, East},
 // }
 
-type Position struct {
-	X, Y int
+var moves = map[Direction]grid.Point{
+	North: grid.North,
+	East:  grid.East,
+	South: grid.South,
+	West:  grid.West,
+}
+
+type Solver struct {
+	tiles *grid.Grid[rune]
+}
+
+func init() {
+	solver.Register(10, func() solver.Solver { return &Solver{} })
 }
 
-func main() {
-	lines, err := readLinesFromFile("../../inputs/input.txt")
+func (s *Solver) Parse(lines []string) error {
+	tiles, err := parseLines(lines)
 
 	if err != nil {
-		log.Fatal("Could not open the input file")
+		return input.InDay(10, err)
 	}
 
-	distance := part1(lines)
-	fmt.Println("Part 1:", distance)
-	area := part2(lines)
-	fmt.Println("Part 2:", area)
+	s.tiles = tiles
+
+	return nil
 }
 
-func part1(lines []string) int {
-	tiles := parseLines(lines)
+func (s *Solver) Part1() (solver.Answer, error) {
+	return solver.Int(getFarthestPoint(s.tiles)), nil
+}
 
-	var startPosition Position
+func (s *Solver) Part2() (solver.Answer, error) {
+	return solver.Int(getEnclosedArea(s.tiles.Clone())), nil
+}
 
-	for i := 0; i < len(tiles); i = i + 1 {
-		for j := 0; j < len(tiles); j = j + 1 {
-			if tiles[i][j] == "S" {
-				startPosition = Position{X: j, Y: i}
-			}
-		}
-	}
+func getFarthestPoint(tiles *grid.Grid[rune]) int {
+	startPosition, _ := tiles.Find('S')
 
 	var pathLenght int
 
@@ -127,64 +135,54 @@ func part1(lines []string) int {
 	return farthestPoint
 }
 
-func part2(lines []string) int {
-	tiles := parseLines(lines)
-
-	var startPosition Position
-
-	for i := 0; i < len(tiles); i = i + 1 {
-		for j := 0; j < len(tiles); j = j + 1 {
-			if tiles[i][j] == "S" {
-				startPosition = Position{X: j, Y: i}
-			}
-		}
-	}
+func getEnclosedArea(tiles *grid.Grid[rune]) int {
+	startPosition, _ := tiles.Find('S')
 
-	var path map[int]map[int]string
+	var path map[int]map[int]rune
 
 	if found, _, foundPath, lastDirection := walkPath(startPosition, North, true, tiles); found {
 		path = foundPath
 		if lastDirection == North {
-			tiles[startPosition.Y][startPosition.X] = "|"
+			tiles.Set(startPosition, '|')
 		}
 		if lastDirection == East {
-			tiles[startPosition.Y][startPosition.X] = "J"
+			tiles.Set(startPosition, 'J')
 		}
 		if lastDirection == West {
-			tiles[startPosition.Y][startPosition.X] = "L"
+			tiles.Set(startPosition, 'L')
 		}
 	} else if found, _, foundPath, lastDirection := walkPath(startPosition, East, true, tiles); found {
 		path = foundPath
 		if lastDirection == East {
-			tiles[startPosition.Y][startPosition.X] = "-"
+			tiles.Set(startPosition, '-')
 		}
 		if lastDirection == North {
-			tiles[startPosition.Y][startPosition.X] = "F"
+			tiles.Set(startPosition, 'F')
 		}
 		if lastDirection == South {
-			tiles[startPosition.Y][startPosition.X] = "L"
+			tiles.Set(startPosition, 'L')
 		}
 	} else if found, _, foundPath, lastDirection := walkPath(startPosition, South, true, tiles); found {
 		path = foundPath
 		if lastDirection == South {
-			tiles[startPosition.Y][startPosition.X] = "|"
+			tiles.Set(startPosition, '|')
 		}
 		if lastDirection == East {
-			tiles[startPosition.Y][startPosition.X] = "7"
+			tiles.Set(startPosition, '7')
 		}
 		if lastDirection == West {
-			tiles[startPosition.Y][startPosition.X] = "F"
+			tiles.Set(startPosition, 'F')
 		}
 	} else if found, _, foundPath, lastDirection := walkPath(startPosition, West, true, tiles); found {
 		path = foundPath
 		if lastDirection == West {
-			tiles[startPosition.Y][startPosition.X] = "-"
+			tiles.Set(startPosition, '-')
 		}
 		if lastDirection == North {
-			tiles[startPosition.Y][startPosition.X] = "J"
+			tiles.Set(startPosition, 'J')
 		}
 		if lastDirection == South {
-			tiles[startPosition.Y][startPosition.X] = "7"
+			tiles.Set(startPosition, '7')
 		}
 	}
 
@@ -193,20 +191,18 @@ func part2(lines []string) int {
 	return area
 }
 
-func calculateArea(path map[int]map[int]string, tiles [][]string) int {
+func calculateArea(path map[int]map[int]rune, tiles *grid.Grid[rune]) int {
 	area := 0
 
-	height := len(tiles)
-
-	for j := 0; j < height; j = j + 1 {
-		count := countTilesInsidePath(path[j], tiles[j])
+	for j := 0; j < tiles.Height(); j = j + 1 {
+		count := countTilesInsidePath(path[j], tiles.Row(j))
 		area = area + count
 	}
 
 	return area
 }
 
-func countTilesInsidePath(path map[int]string, tiles []string) int {
+func countTilesInsidePath(path map[int]rune, tiles []rune) int {
 	if len(path) == 0 {
 		return 0
 	}
@@ -214,36 +210,36 @@ func countTilesInsidePath(path map[int]string, tiles []string) int {
 	count := 0
 
 	isInside := false
-	previousCorner := ""
+	var previousCorner rune
 	for i := 0; i < len(tiles); i = i + 1 {
 		symbol := tiles[i]
 		_, isPath := path[i]
 
-		if isPath && symbol == "|" {
+		if isPath && symbol == '|' {
 			isInside = !isInside
 			continue
 		}
 
-		if isPath && symbol == "-" {
+		if isPath && symbol == '-' {
 			continue
 		}
 
 		if isPath {
-			if previousCorner == "" {
+			if previousCorner == 0 {
 				previousCorner = symbol
 				continue
 			}
 
 			// FJ or L7
-			if (previousCorner == "F" && symbol == "J") || (previousCorner == "L" && symbol == "7") {
+			if (previousCorner == 'F' && symbol == 'J') || (previousCorner == 'L' && symbol == '7') {
 				isInside = !isInside
-				previousCorner = ""
+				previousCorner = 0
 				continue
 			}
 
 			// F7 or LJ
-			if (previousCorner == "F" && symbol == "7") || (previousCorner == "L" && symbol == "J") {
-				previousCorner = ""
+			if (previousCorner == 'F' && symbol == '7') || (previousCorner == 'L' && symbol == 'J') {
+				previousCorner = 0
 				continue
 			}
 		}
@@ -256,19 +252,19 @@ func countTilesInsidePath(path map[int]string, tiles []string) int {
 	return count
 }
 
-func walkPath(current Position, goesTo Direction, start bool, tiles [][]string) (bool, int, map[int]map[int]string, Direction) {
-	symbol := tiles[current.Y][current.X]
+func walkPath(current grid.Point, goesTo Direction, start bool, tiles *grid.Grid[rune]) (bool, int, map[int]map[int]rune, Direction) {
+	symbol := tiles.At(current)
 
 	switch symbol {
-	case "-":
+	case '-':
 		if goesTo != East && goesTo != West {
 			return false, 0, nil, goesTo
 		}
-	case "|":
+	case '|':
 		if goesTo != North && goesTo != South {
 			return false, 0, nil, goesTo
 		}
-	case "L":
+	case 'L':
 		if goesTo == South {
 			goesTo = East
 		} else if goesTo == West {
@@ -276,7 +272,7 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 		} else {
 			return false, 0, nil, goesTo
 		}
-	case "J":
+	case 'J':
 		if goesTo == East {
 			goesTo = North
 		} else if goesTo == South {
@@ -284,7 +280,7 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 		} else {
 			return false, 0, nil, goesTo
 		}
-	case "7":
+	case '7':
 		if goesTo == East {
 			goesTo = South
 		} else if goesTo == North {
@@ -292,7 +288,7 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 		} else {
 			return false, 0, nil, goesTo
 		}
-	case "F":
+	case 'F':
 		if goesTo == North {
 			goesTo = East
 		} else if goesTo == West {
@@ -300,10 +296,10 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 		} else {
 			return false, 0, nil, goesTo
 		}
-	case "S":
+	case 'S':
 		if !start {
-			path := make(map[int]map[int]string)
-			path[current.Y] = make(map[int]string)
+			path := make(map[int]map[int]rune)
+			path[current.Y] = make(map[int]rune)
 			path[current.Y][current.X] = symbol
 			return true, 0, path, goesTo
 		}
@@ -311,23 +307,13 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 		return false, 0, nil, goesTo
 	}
 
-	var nextPosition Position
-	switch goesTo {
-	case East:
-		nextPosition = Position{X: current.X + 1, Y: current.Y}
-	case West:
-		nextPosition = Position{X: current.X - 1, Y: current.Y}
-	case North:
-		nextPosition = Position{X: current.X, Y: current.Y - 1}
-	case South:
-		nextPosition = Position{X: current.X, Y: current.Y + 1}
-	}
+	nextPosition := current.Add(moves[goesTo])
 
 	found, sum, path, lastDirection := walkPath(nextPosition, goesTo, false, tiles)
 
 	if found {
 		if _, ok := path[current.Y]; !ok {
-			path[current.Y] = make(map[int]string)
+			path[current.Y] = make(map[int]rune)
 		}
 		path[current.Y][current.X] = symbol
 		return true, sum + 1, path, lastDirection
@@ -336,42 +322,45 @@ func walkPath(current Position, goesTo Direction, start bool, tiles [][]string)
 	return false, 0, nil, goesTo
 }
 
-func parseLines(lines []string) [][]string {
-	var tiles [][]string
-
-	width := utf8.RuneCountInString(lines[0]) + 2
-	tiles = append(tiles, strings.Split(strings.Repeat(".", width), ""))
+const tileSymbols = "|-LJ7F.S"
 
-	for _, line := range lines {
-		line = "." + line + "."
-		tiles = append(tiles, strings.Split(line, ""))
+func parseLines(lines []string) (*grid.Grid[rune], error) {
+	if len(lines) == 0 {
+		return nil, input.Errorf(1, 0, "", "empty field")
 	}
 
-	tiles = append(tiles, tiles[0])
-
-	return tiles
-}
+	width := utf8.RuneCountInString(lines[0])
+	starts := 0
 
-func readLinesFromFile(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-
-	if err != nil {
-		return nil, err
-	}
+	for index, line := range lines {
+		if utf8.RuneCountInString(line) != width {
+			return nil, input.Errorf(index+1, 0, line, "expected %d tiles like the first line", width)
+		}
 
-	defer file.Close()
+		for column, symbol := range line {
+			if !strings.ContainsRune(tileSymbols, symbol) {
+				return nil, input.Errorf(index+1, column+1, string(symbol), "invalid tile")
+			}
 
-	scanner := bufio.NewScanner(file)
+			if symbol == 'S' {
+				starts = starts + 1
+			}
 
-	var lines []string
+			if starts > 1 {
+				return nil, input.Errorf(index+1, column+1, string(symbol), "second starting position")
+			}
+		}
+	}
 
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	if starts == 0 {
+		return nil, input.Errorf(0, 0, "", "missing starting position")
 	}
 
-	if err := scanner.Err(); err != nil {
+	tiles, err := grid.FromLines(lines)
+
+	if err != nil {
 		return nil, err
 	}
 
-	return lines, nil
+	return tiles.Pad(1, '.'), nil
 }
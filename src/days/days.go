@@ -0,0 +1,16 @@
+// Package days links the solver of every day into the solver registry.
+// Importing it for its side effects is all a command needs to reach them.
+package days
+
+import (
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day01"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day02"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day03"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day04"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day05"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day06"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day07"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day08"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day09"
+	_ "github.com/gabrielgry/advent-of-code-2023/src/day10"
+)
@@ -0,0 +1,159 @@
+package days_test
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "github.com/gabrielgry/advent-of-code-2023/src/days"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+// privateInputs resolves inputs from $AOC_INPUTS, defaulting to the inputs
+// directory at the repository root.
+func privateInputs() input.Resolver {
+	if dir := os.Getenv(input.DirEnv); dir != "" {
+		return input.NewResolver(dir)
+	}
+
+	return input.NewResolver(filepath.Join("..", "..", input.DefaultDir))
+}
+
+type expectedAnswer struct {
+	Day    int
+	Part   int
+	Input  string
+	Answer string
+}
+
+func readAnswers(t *testing.T) []expectedAnswer {
+	t.Helper()
+
+	data, err := testdata.ReadFile("testdata/answers.txt")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := input.ReadLinesFrom(bytes.NewReader(data))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var answers []expectedAnswer
+
+	for index, line := range lines {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) != 4 {
+			t.Fatalf("answers.txt:%d: expected 4 fields, found %d", index+1, len(fields))
+		}
+
+		day, err := strconv.Atoi(fields[0])
+
+		if err != nil {
+			t.Fatalf("answers.txt:%d: invalid day %q", index+1, fields[0])
+		}
+
+		part, err := strconv.Atoi(fields[1])
+
+		if err != nil {
+			t.Fatalf("answers.txt:%d: invalid part %q", index+1, fields[1])
+		}
+
+		answers = append(answers, expectedAnswer{Day: day, Part: part, Input: fields[2], Answer: fields[3]})
+	}
+
+	return answers
+}
+
+func readInput(day int, name string) ([]string, error) {
+	dayDirectory := fmt.Sprintf("day%02d", day)
+
+	data, err := testdata.ReadFile("testdata/" + dayDirectory + "/" + name + ".txt")
+
+	if errors.Is(err, fs.ErrNotExist) {
+		data, err = os.ReadFile(privateInputs().Path(day, name))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return input.ReadLinesFrom(bytes.NewReader(data))
+}
+
+func TestAnswers(t *testing.T) {
+	for _, expected := range readAnswers(t) {
+		expected := expected
+		name := fmt.Sprintf("day%02d/part%d/%s", expected.Day, expected.Part, expected.Input)
+
+		t.Run(name, func(t *testing.T) {
+			lines, err := readInput(expected.Day, expected.Input)
+
+			if errors.Is(err, fs.ErrNotExist) {
+				t.Skip("input not found")
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := solver.New(expected.Day)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.Parse(lines); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			answer, err := solver.Solve(s, expected.Part)
+
+			if err != nil {
+				t.Fatalf("Part%d: %v", expected.Part, err)
+			}
+
+			if answer.String() != expected.Answer {
+				t.Errorf("Part%d = %s, want %s", expected.Part, answer, expected.Answer)
+			}
+
+			streamer, ok := s.(solver.Streamer)
+
+			if !ok {
+				return
+			}
+
+			streamed, err := streamer.Stream(expected.Part, strings.NewReader(strings.Join(lines, "\n")), input.DefaultMaxLineLength)
+
+			if errors.Is(err, solver.ErrNotImplemented) {
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Stream(%d): %v", expected.Part, err)
+			}
+
+			if streamed.String() != expected.Answer {
+				t.Errorf("Stream(%d) = %s, want %s", expected.Part, streamed, expected.Answer)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package days_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+	"github.com/gabrielgry/advent-of-code-2023/src/solver"
+)
+
+func TestMalformedInput(t *testing.T) {
+	tests := []struct {
+		day    int
+		lines  []string
+		line   int
+		column int
+		text   string
+	}{
+		{2, []string{"Game 1: 3 blue, 4 red", "Game 2 3 blue"}, 2, 1, "Game 2 3 blue"},
+		{2, []string{"Game 1: 3 blue; x red"}, 1, 17, "x"},
+		{2, []string{"Game 1: 3 blue,, 4 red"}, 1, 16, ""},
+		{2, []string{"Game x: 3 blue"}, 1, 6, "x"},
+		{3, []string{"467..114..", "...*......", "..35..63"}, 3, 0, "..35..63"},
+		{4, []string{"Card 1: 41 48 | 83 86", "Card 2: 13 3x | 61 30"}, 2, 12, "3x"},
+		{4, []string{"Card 1: 41 48 | 83 8x6"}, 1, 20, "8x6"},
+		{4, []string{"Card x: 41 48 | 83 86"}, 1, 6, "x"},
+		{4, []string{"Card 0: 41 48 | 83 86"}, 1, 6, "0"},
+		{4, []string{"Card 1: 41 | 83", "Card 1: 48 | 86"}, 2, 0, "Card 1: 48 | 86"},
+		{5, []string{"seed: 79 14", "", "seed-to-soil map:", "50 98 2"}, 1, 1, "seed: 79 14"},
+		{5, []string{"seeds: 79 14", "", "seed-to-soil map:", "50 98 2", "52 5o 48"}, 5, 4, "5o"},
+		{5, []string{"seeds: 79 14", "", "seed-soil map:", "50 98 2"}, 3, 1, "seed-soil"},
+		{5, []string{"seeds: 10 5", "", "seed-to-location map:", "0 12 0"}, 4, 6, "0"},
+		{5, []string{"seeds: 10 5", "", "seed-to-location map:", "0 12  -3"}, 4, 7, "-3"},
+		{5, []string{"seeds: 79 14", "", "seed-to-soil map:", "50 98 2", "", "seed-to-soil map:", "52 50 48"}, 6, 1, "seed-to-soil"},
+		{6, []string{"Time: 7 15", "Distance: 9 4O"}, 2, 13, "4O"},
+		{6, []string{"Time: 7 15", "Distance: 9"}, 2, 1, "Distance: 9"},
+		{7, []string{"32T3K 765", "T55X5 684"}, 2, 4, "X"},
+		{7, []string{"32T3K seven"}, 1, 7, "seven"},
+		{8, []string{"RLX", "", "AAA = (AAA, AAA)"}, 1, 3, "X"},
+		{8, []string{"", "", "AAA = (AAA, AAA)"}, 1, 1, ""},
+		{8, []string{"RL", "", "AAA = (BBB, CCC)", "BBB = (BBB, BBB)"}, 3, 0, "CCC"},
+		{8, []string{"RL", "", "AAA = (AAA, BBB)", "DDD = (EEE, CCC)", "FFF = (GGG, HHH)"}, 3, 0, "BBB"},
+		{9, []string{"0 3 6", "1 3 six"}, 2, 5, "six"},
+		{10, []string{".S-7.", ".|.X.", ".L-J."}, 2, 4, "X"},
+		{10, []string{".S-7.", ".|.|", ".L-J."}, 2, 0, ".|.|"},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprintf("day%02d/line%d", test.day, test.line), func(t *testing.T) {
+			s, err := solver.New(test.day)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.Parse(test.lines)
+
+			var parseError *input.ParseError
+
+			if !errors.As(err, &parseError) {
+				t.Fatalf("Parse error = %v, want a *input.ParseError", err)
+			}
+
+			if parseError.Day != test.day || parseError.Line != test.line || parseError.Column != test.column || parseError.Text != test.text {
+				t.Errorf("Parse error at day %d, line %d, column %d, text %q, want day %d, line %d, column %d, text %q",
+					parseError.Day, parseError.Line, parseError.Column, parseError.Text,
+					test.day, test.line, test.column, test.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+// Package grid holds bounded two-dimensional grids of cells, such as the
+// character maps many puzzles are drawn in.
+package grid
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Point is the position of a cell. X grows to the right and Y downwards.
+type Point struct {
+	X, Y int
+}
+
+// Add returns p moved by q.
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// The unit moves towards each neighboring cell.
+var (
+	North     = Point{X: 0, Y: -1}
+	NorthEast = Point{X: 1, Y: -1}
+	East      = Point{X: 1, Y: 0}
+	SouthEast = Point{X: 1, Y: 1}
+	South     = Point{X: 0, Y: 1}
+	SouthWest = Point{X: -1, Y: 1}
+	West      = Point{X: -1, Y: 0}
+	NorthWest = Point{X: -1, Y: -1}
+)
+
+// Directions4 are the moves to the cells sharing a side, clockwise from
+// north.
+var Directions4 = []Point{North, East, South, West}
+
+// Directions8 are the moves to the cells sharing a side or a corner,
+// clockwise from north.
+var Directions8 = []Point{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+// RaggedError is returned for lines that are not as wide as the first one.
+type RaggedError struct {
+	Row   int
+	Width int
+	Want  int
+}
+
+func (e *RaggedError) Error() string {
+	return fmt.Sprintf("row %d has %d cells, want %d", e.Row, e.Width, e.Want)
+}
+
+// Grid is a rectangular grid of cells stored row by row.
+type Grid[T comparable] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+// New returns a width by height grid with every cell set to fill.
+func New[T comparable](width int, height int, fill T) *Grid[T] {
+	g := &Grid[T]{width: width, height: height, cells: make([]T, width*height)}
+
+	for i := range g.cells {
+		g.cells[i] = fill
+	}
+
+	return g
+}
+
+// FromLines returns a grid with a rune cell per character of lines. Every
+// line must be as wide as the first one.
+func FromLines(lines []string) (*Grid[rune], error) {
+	return fromLines(lines, utf8.RuneCountInString, func(line string) []rune { return []rune(line) })
+}
+
+// BytesFromLines returns a grid with a byte cell per byte of lines. Every
+// line must be as wide as the first one.
+func BytesFromLines(lines []string) (*Grid[byte], error) {
+	return fromLines(lines, func(line string) int { return len(line) }, func(line string) []byte { return []byte(line) })
+}
+
+func fromLines[T comparable](lines []string, width func(string) int, cells func(string) []T) (*Grid[T], error) {
+	g := &Grid[T]{height: len(lines)}
+
+	if len(lines) > 0 {
+		g.width = width(lines[0])
+	}
+
+	g.cells = make([]T, 0, g.width*g.height)
+
+	for y, line := range lines {
+		row := cells(line)
+
+		if len(row) != g.width {
+			return nil, &RaggedError{Row: y, Width: len(row), Want: g.width}
+		}
+
+		g.cells = append(g.cells, row...)
+	}
+
+	return g, nil
+}
+
+// Width returns the number of columns.
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+// Height returns the number of rows.
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// In reports whether p is inside the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Get returns the cell at p, or false when p is outside the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// At returns the cell at p, or the zero value when p is outside the grid.
+func (g *Grid[T]) At(p Point) T {
+	cell, _ := g.Get(p)
+	return cell
+}
+
+// Set changes the cell at p, reporting false when p is outside the grid.
+func (g *Grid[T]) Set(p Point, cell T) bool {
+	if !g.In(p) {
+		return false
+	}
+
+	g.cells[p.Y*g.width+p.X] = cell
+
+	return true
+}
+
+// Row returns a copy of row y.
+func (g *Grid[T]) Row(y int) []T {
+	return append([]T(nil), g.cells[y*g.width:(y+1)*g.width]...)
+}
+
+// Find returns the first point holding cell, reading row by row.
+func (g *Grid[T]) Find(cell T) (Point, bool) {
+	for i, current := range g.cells {
+		if current == cell {
+			return Point{X: i % g.width, Y: i / g.width}, true
+		}
+	}
+
+	return Point{}, false
+}
+
+// Neighbors4 returns the points inside the grid sharing a side with p.
+func (g *Grid[T]) Neighbors4(p Point) []Point {
+	return g.neighbors(p, Directions4)
+}
+
+// Neighbors8 returns the points inside the grid sharing a side or a corner
+// with p.
+func (g *Grid[T]) Neighbors8(p Point) []Point {
+	return g.neighbors(p, Directions8)
+}
+
+func (g *Grid[T]) neighbors(p Point, directions []Point) []Point {
+	neighbors := make([]Point, 0, len(directions))
+
+	for _, direction := range directions {
+		if neighbor := p.Add(direction); g.In(neighbor) {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	return neighbors
+}
+
+// Segment is a horizontal run of cells from Start to End, both included, on
+// row Y.
+type Segment struct {
+	Y     int
+	Start int
+	End   int
+}
+
+// Segments returns the longest runs of consecutive cells on a row that match,
+// row by row and left to right.
+func (g *Grid[T]) Segments(match func(cell T) bool) []Segment {
+	var segments []Segment
+
+	for y := 0; y < g.height; y = y + 1 {
+		start := -1
+
+		for x := 0; x <= g.width; x = x + 1 {
+			matched := x < g.width && match(g.cells[y*g.width+x])
+
+			if matched && start == -1 {
+				start = x
+			}
+
+			if !matched && start != -1 {
+				segments = append(segments, Segment{Y: y, Start: start, End: x - 1})
+				start = -1
+			}
+		}
+	}
+
+	return segments
+}
+
+// Cells returns the cells of the segment.
+func (g *Grid[T]) Cells(s Segment) []T {
+	return append([]T(nil), g.cells[s.Y*g.width+s.Start:s.Y*g.width+s.End+1]...)
+}
+
+// Around returns the points inside the grid that share a side or a corner
+// with the segment, row by row.
+func (g *Grid[T]) Around(s Segment) []Point {
+	var points []Point
+
+	for y := s.Y - 1; y <= s.Y+1; y = y + 1 {
+		for x := s.Start - 1; x <= s.End+1; x = x + 1 {
+			point := Point{X: x, Y: y}
+
+			if y == s.Y && x >= s.Start && x <= s.End || !g.In(point) {
+				continue
+			}
+
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+// Region returns the points connected to start through cells sharing a side
+// that match, start included, in the order they are reached. It is empty
+// when the cell at start does not match.
+func (g *Grid[T]) Region(start Point, match func(cell T) bool) []Point {
+	if cell, ok := g.Get(start); !ok || !match(cell) {
+		return nil
+	}
+
+	seen := map[Point]bool{start: true}
+	region := []Point{start}
+
+	for i := 0; i < len(region); i = i + 1 {
+		for _, neighbor := range g.Neighbors4(region[i]) {
+			if seen[neighbor] || !match(g.At(neighbor)) {
+				continue
+			}
+
+			seen[neighbor] = true
+			region = append(region, neighbor)
+		}
+	}
+
+	return region
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{width: g.width, height: g.height, cells: append([]T(nil), g.cells...)}
+}
+
+// Pad returns a copy of the grid surrounded by n rows and columns of fill.
+func (g *Grid[T]) Pad(n int, fill T) *Grid[T] {
+	padded := New(g.width+2*n, g.height+2*n, fill)
+
+	for y := 0; y < g.height; y = y + 1 {
+		copy(padded.cells[(y+n)*padded.width+n:], g.cells[y*g.width:(y+1)*g.width])
+	}
+
+	return padded
+}
+
+// Transpose returns a copy of the grid mirrored along its main diagonal, its
+// rows becoming columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.height, g.width, func(p Point) Point { return Point{X: p.Y, Y: p.X} })
+}
+
+// RotateClockwise returns a copy of the grid turned a quarter clockwise.
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(p Point) Point { return Point{X: g.height - 1 - p.Y, Y: p.X} })
+}
+
+// RotateCounterClockwise returns a copy of the grid turned a quarter
+// counterclockwise.
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	return g.remap(g.height, g.width, func(p Point) Point { return Point{X: p.Y, Y: g.width - 1 - p.X} })
+}
+
+// remap returns a width by height grid holding every cell of g at the point
+// move sends it to.
+func (g *Grid[T]) remap(width int, height int, move func(p Point) Point) *Grid[T] {
+	remapped := &Grid[T]{width: width, height: height, cells: make([]T, len(g.cells))}
+
+	for i, cell := range g.cells {
+		to := move(Point{X: i % g.width, Y: i / g.width})
+		remapped.cells[to.Y*width+to.X] = cell
+	}
+
+	return remapped
+}
+
+// Lines returns every row of a character grid as a string.
+func Lines[T ~byte | ~rune](g *Grid[T]) []string {
+	lines := make([]string, g.height)
+
+	for y := 0; y < g.height; y = y + 1 {
+		row := make([]rune, g.width)
+
+		for x, cell := range g.cells[y*g.width : (y+1)*g.width] {
+			row[x] = rune(cell)
+		}
+
+		lines[y] = string(row)
+	}
+
+	return lines
+}
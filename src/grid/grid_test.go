@@ -0,0 +1,155 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func mustFromLines(t *testing.T, lines ...string) *Grid[rune] {
+	t.Helper()
+
+	g, err := FromLines(lines)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestFromLines(t *testing.T) {
+	g := mustFromLines(t, "ab", "cd", "ef")
+
+	if g.Width() != 2 || g.Height() != 3 {
+		t.Fatalf("size = %dx%d, want 2x3", g.Width(), g.Height())
+	}
+
+	if got := g.At(Point{X: 1, Y: 2}); got != 'f' {
+		t.Errorf("At(1, 2) = %q, want 'f'", got)
+	}
+
+	var raggedError *RaggedError
+
+	if _, err := FromLines([]string{"ab", "c"}); !errors.As(err, &raggedError) || raggedError.Row != 1 {
+		t.Errorf("FromLines of ragged lines error = %v, want a *RaggedError on row 1", err)
+	}
+
+	bytes, err := BytesFromLines([]string{"xy"})
+
+	if err != nil || bytes.At(Point{X: 1}) != 'y' {
+		t.Errorf("BytesFromLines = %v, %v", bytes, err)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	g := mustFromLines(t, "ab", "cd")
+
+	for _, p := range []Point{{-1, 0}, {0, -1}, {2, 0}, {0, 2}} {
+		if cell, ok := g.Get(p); ok || cell != 0 {
+			t.Errorf("Get(%v) = %q, %v, want 0, false", p, cell, ok)
+		}
+
+		if g.Set(p, 'x') {
+			t.Errorf("Set(%v) = true outside the grid", p)
+		}
+	}
+
+	if !g.Set(Point{X: 1, Y: 1}, 'x') || g.At(Point{X: 1, Y: 1}) != 'x' {
+		t.Errorf("Set(1, 1) did not change the cell")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(3, 3, '.')
+
+	tests := []struct {
+		point Point
+		four  int
+		eight int
+	}{
+		{Point{X: 0, Y: 0}, 2, 3},
+		{Point{X: 1, Y: 0}, 3, 5},
+		{Point{X: 1, Y: 1}, 4, 8},
+	}
+
+	for _, test := range tests {
+		if got := len(g.Neighbors4(test.point)); got != test.four {
+			t.Errorf("len(Neighbors4(%v)) = %d, want %d", test.point, got, test.four)
+		}
+
+		if got := len(g.Neighbors8(test.point)); got != test.eight {
+			t.Errorf("len(Neighbors8(%v)) = %d, want %d", test.point, got, test.eight)
+		}
+	}
+}
+
+func TestSegments(t *testing.T) {
+	g := mustFromLines(t, "467..114", "...*....", "..35..63")
+
+	segments := g.Segments(unicode.IsDigit)
+	want := []Segment{{0, 0, 2}, {0, 5, 7}, {2, 2, 3}, {2, 6, 7}}
+
+	if !reflect.DeepEqual(segments, want) {
+		t.Fatalf("Segments = %v, want %v", segments, want)
+	}
+
+	if got := string(g.Cells(segments[1])); got != "114" {
+		t.Errorf("Cells(%v) = %q, want \"114\"", segments[1], got)
+	}
+
+	around := g.Around(segments[2])
+	want8 := fmt.Sprint([]Point{{1, 1}, {2, 1}, {3, 1}, {4, 1}, {1, 2}, {4, 2}})
+
+	if fmt.Sprint(around) != want8 {
+		t.Errorf("Around(%v) = %v, want %v", segments[2], around, want8)
+	}
+}
+
+func TestRegion(t *testing.T) {
+	g := mustFromLines(t, "..#", ".##", "#..")
+
+	region := g.Region(Point{}, func(cell rune) bool { return cell == '.' })
+
+	if len(region) != 3 {
+		t.Errorf("Region from (0, 0) = %v, want 3 points", region)
+	}
+
+	if region := g.Region(Point{X: 2}, func(cell rune) bool { return cell == '.' }); region != nil {
+		t.Errorf("Region from a wall = %v, want nil", region)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	g := mustFromLines(t, "abc", "def")
+
+	tests := []struct {
+		name string
+		got  *Grid[rune]
+		want []string
+	}{
+		{"Pad", g.Pad(1, '.'), []string{".....", ".abc.", ".def.", "....."}},
+		{"Transpose", g.Transpose(), []string{"ad", "be", "cf"}},
+		{"RotateClockwise", g.RotateClockwise(), []string{"da", "eb", "fc"}},
+		{"RotateCounterClockwise", g.RotateCounterClockwise(), []string{"cf", "be", "ad"}},
+	}
+
+	for _, test := range tests {
+		if got := Lines(test.got); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s = %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	clone := g.Clone()
+	clone.Set(Point{}, 'z')
+
+	if g.At(Point{}) != 'a' {
+		t.Errorf("changing a clone changed the original")
+	}
+
+	if p, ok := g.Find('e'); !ok || p != (Point{X: 1, Y: 1}) {
+		t.Errorf("Find('e') = %v, %v, want (1, 1), true", p, ok)
+	}
+}
@@ -0,0 +1,94 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError locates malformed input. Line and Column are 1-based; a zero
+// Day, Line or Column is unknown.
+type ParseError struct {
+	Day    int
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	var location []string
+
+	if e.Day != 0 {
+		location = append(location, fmt.Sprintf("day %d", e.Day))
+	}
+
+	if e.Line != 0 {
+		position := fmt.Sprintf("line %d", e.Line)
+
+		if e.Column != 0 {
+			position = position + fmt.Sprintf(", column %d", e.Column)
+		}
+
+		location = append(location, position)
+	}
+
+	message := e.Err.Error()
+
+	if e.Text != "" {
+		message = fmt.Sprintf("%s: %q", message, e.Text)
+	}
+
+	if len(location) == 0 {
+		return message
+	}
+
+	return strings.Join(location, ": ") + ": " + message
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns a *ParseError for text found at line and column.
+func Errorf(line int, column int, text string, format string, args ...any) error {
+	return &ParseError{Line: line, Column: column, Text: text, Err: fmt.Errorf(format, args...)}
+}
+
+// Locate places err on line, shifting its column by offset, for errors
+// returned by helpers that only see part of a line. Errors that are not a
+// *ParseError are wrapped in one.
+func Locate(err error, line int, offset int) error {
+	var parseError *ParseError
+
+	if !errors.As(err, &parseError) {
+		return &ParseError{Line: line, Err: err}
+	}
+
+	located := *parseError
+	located.Line = line
+
+	if located.Column != 0 {
+		located.Column = located.Column + offset
+	}
+
+	return &located
+}
+
+// InDay records the day whose input err was found in.
+func InDay(day int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var parseError *ParseError
+
+	if !errors.As(err, &parseError) {
+		return &ParseError{Day: day, Err: err}
+	}
+
+	located := *parseError
+	located.Day = day
+
+	return &located
+}
@@ -0,0 +1,53 @@
+package input_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+func TestLocate(t *testing.T) {
+	_, err := input.ParseNumbers("1 x")
+	err = input.InDay(6, input.Locate(err, 4, len("Time:")))
+
+	var parseError *input.ParseError
+
+	if !errors.As(err, &parseError) || parseError.Day != 6 || parseError.Line != 4 || parseError.Column != 8 {
+		t.Fatalf("located error = %v, want day 6, line 4, column 8", err)
+	}
+
+	if want := `day 6: line 4, column 8: not a number: "x"`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	unknownColumn := input.Locate(input.Errorf(1, 0, "", "missing seeds"), 9, 5)
+
+	if want := "line 9: missing seeds"; unknownColumn.Error() != want {
+		t.Errorf("Locate kept column 0 as %q, want %q", unknownColumn.Error(), want)
+	}
+}
+
+func TestLocateWraps(t *testing.T) {
+	cause := errors.New("cause")
+
+	err := input.InDay(2, input.Locate(cause, 3, 10))
+
+	var parseError *input.ParseError
+
+	if !errors.As(err, &parseError) || parseError.Day != 2 || parseError.Line != 3 || parseError.Column != 0 {
+		t.Fatalf("wrapped error = %v, want day 2, line 3 and no column", err)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("wrapped error %v does not unwrap to its cause", err)
+	}
+
+	if inDay := input.InDay(5, cause); inDay.Error() != "day 5: cause" {
+		t.Errorf("InDay(5, cause) = %q, want \"day 5: cause\"", inDay.Error())
+	}
+
+	if input.InDay(1, nil) != nil {
+		t.Errorf("InDay(1, nil) is not nil")
+	}
+}
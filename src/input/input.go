@@ -0,0 +1,185 @@
+package input
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNotANumber is wrapped by the errors of fields that are not integers.
+var ErrNotANumber = errors.New("not a number")
+
+// ReadLines returns every line of the named file without line terminators.
+func ReadLines(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return ReadLinesFrom(file)
+}
+
+// DefaultMaxLineLength is the longest line read when no other limit is
+// given.
+const DefaultMaxLineLength = 1 << 20
+
+// ReadLinesFrom returns every line read from r without line terminators.
+func ReadLinesFrom(r io.Reader) ([]string, error) {
+	return ReadLinesLimit(r, DefaultMaxLineLength)
+}
+
+// ReadLinesLimit returns every line read from r without line terminators,
+// failing on lines longer than maxLineLength bytes.
+func ReadLinesLimit(r io.Reader, maxLineLength int) ([]string, error) {
+	var lines []string
+
+	err := EachLine(r, maxLineLength, func(lineNumber int, line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// EachLine calls fn with every line read from r and its 1-based number,
+// holding a single line in memory at a time. It stops at the first error
+// returned by fn and fails on lines longer than maxLineLength bytes.
+func EachLine(r io.Reader, maxLineLength int, fn func(lineNumber int, line string) error) error {
+	if maxLineLength <= 0 {
+		maxLineLength = DefaultMaxLineLength
+	}
+
+	// The buffer holds the line terminator too, and the scanner accepts
+	// tokens as long as the larger of its capacity and limit.
+	bufferSize := maxLineLength + 1
+	initialSize := bufferSize
+
+	if initialSize > bufio.MaxScanTokenSize {
+		initialSize = bufio.MaxScanTokenSize
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, initialSize), bufferSize)
+
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber = lineNumber + 1
+
+		if err := fn(lineNumber, scanner.Text()); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return Errorf(lineNumber+1, 0, "", "line longer than %d bytes", maxLineLength)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Section is a group of consecutive non-blank lines. Line is the 1-based
+// line number of its first line.
+type Section struct {
+	Line  int
+	Lines []string
+}
+
+// SplitSections groups lines into sections separated by one or more blank
+// lines. Blank lines are not included in any section.
+func SplitSections(lines []string) []Section {
+	var sections []Section
+	var section Section
+
+	for index, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if len(section.Lines) > 0 {
+				sections = append(sections, section)
+				section = Section{}
+			}
+
+			continue
+		}
+
+		if len(section.Lines) == 0 {
+			section.Line = index + 1
+		}
+
+		section.Lines = append(section.Lines, line)
+	}
+
+	if len(section.Lines) > 0 {
+		sections = append(sections, section)
+	}
+
+	return sections
+}
+
+// Field is a whitespace separated field and its 1-based column.
+type Field struct {
+	Text   string
+	Column int
+}
+
+// SplitFields splits s around runs of whitespace like strings.Fields,
+// keeping the column every field starts at.
+func SplitFields(s string) []Field {
+	var fields []Field
+
+	start := -1
+
+	for index, char := range s {
+		if unicode.IsSpace(char) {
+			if start >= 0 {
+				fields = append(fields, Field{Text: s[start:index], Column: start + 1})
+				start = -1
+			}
+
+			continue
+		}
+
+		if start < 0 {
+			start = index
+		}
+	}
+
+	if start >= 0 {
+		fields = append(fields, Field{Text: s[start:], Column: start + 1})
+	}
+
+	return fields
+}
+
+// ParseNumbers converts the whitespace separated fields of s to ints. A
+// failure is a *ParseError whose column is relative to s.
+func ParseNumbers(s string) ([]int, error) {
+	fields := SplitFields(s)
+	numbers := make([]int, 0, len(fields))
+
+	for _, field := range fields {
+		number, err := strconv.Atoi(field.Text)
+
+		if err != nil {
+			return nil, &ParseError{Column: field.Column, Text: field.Text, Err: ErrNotANumber}
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
@@ -0,0 +1,107 @@
+package input_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+func TestReadLinesFrom(t *testing.T) {
+	lines, err := input.ReadLinesFrom(strings.NewReader("a\r\n\nb c\n"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"a", "", "b c"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("ReadLinesFrom = %q, want %q", lines, want)
+	}
+}
+
+func TestEachLineTooLong(t *testing.T) {
+	var read []int
+
+	err := input.EachLine(strings.NewReader("abc\nabcd\nabcdefgh\nab\n"), 4, func(lineNumber int, line string) error {
+		read = append(read, lineNumber)
+		return nil
+	})
+
+	var parseError *input.ParseError
+
+	if !errors.As(err, &parseError) || parseError.Line != 3 {
+		t.Fatalf("EachLine error = %v, want a *input.ParseError on line 3", err)
+	}
+
+	if !reflect.DeepEqual(read, []int{1, 2}) {
+		t.Errorf("EachLine read lines %v before failing, want [1 2]", read)
+	}
+
+	if _, err := input.ReadLinesLimit(strings.NewReader("abcdefgh"), 4); !errors.As(err, &parseError) || parseError.Line != 1 {
+		t.Errorf("ReadLinesLimit error = %v, want a *input.ParseError on line 1", err)
+	}
+}
+
+func TestEachLineStops(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+
+	err := input.EachLine(strings.NewReader("a\nb\nc\n"), 0, func(lineNumber int, line string) error {
+		calls = calls + 1
+
+		if line == "b" {
+			return stop
+		}
+
+		return nil
+	})
+
+	if err != stop || calls != 2 {
+		t.Errorf("EachLine = %v after %d calls, want the error of fn after 2 calls", err, calls)
+	}
+}
+
+func TestSplitSections(t *testing.T) {
+	sections := input.SplitSections([]string{"", "a", "b", "", " ", "c", ""})
+
+	want := []input.Section{
+		{Line: 2, Lines: []string{"a", "b"}},
+		{Line: 6, Lines: []string{"c"}},
+	}
+
+	if !reflect.DeepEqual(sections, want) {
+		t.Errorf("SplitSections = %+v, want %+v", sections, want)
+	}
+}
+
+func TestSplitFields(t *testing.T) {
+	fields := input.SplitFields("  12 ab\t 3")
+
+	want := []input.Field{{Text: "12", Column: 3}, {Text: "ab", Column: 6}, {Text: "3", Column: 10}}
+
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("SplitFields = %+v, want %+v", fields, want)
+	}
+
+	if fields := input.SplitFields(" \t "); len(fields) != 0 {
+		t.Errorf("SplitFields of blanks = %+v, want none", fields)
+	}
+}
+
+func TestParseNumbers(t *testing.T) {
+	numbers, err := input.ParseNumbers(" 7 -3  42")
+
+	if err != nil || !reflect.DeepEqual(numbers, []int{7, -3, 42}) {
+		t.Errorf("ParseNumbers = %v, %v, want [7 -3 42]", numbers, err)
+	}
+
+	_, err = input.ParseNumbers("1 2x 3")
+
+	var parseError *input.ParseError
+
+	if !errors.As(err, &parseError) || parseError.Column != 3 || parseError.Text != "2x" || !errors.Is(err, input.ErrNotANumber) {
+		t.Errorf("ParseNumbers error = %v, want ErrNotANumber for \"2x\" at column 3", err)
+	}
+}
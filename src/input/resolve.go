@@ -0,0 +1,92 @@
+package input
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// DirEnv names the environment variable that overrides DefaultDir.
+	DirEnv = "AOC_INPUTS"
+	// NameEnv names the environment variable that overrides DefaultName.
+	NameEnv = "AOC_INPUT_NAME"
+
+	DefaultDir  = "inputs"
+	DefaultName = "input"
+)
+
+// Resolver locates named puzzle inputs laid out as Dir/dayNN/<name>.txt, so a
+// day can keep the examples, the real input and each teammate's input side
+// by side.
+type Resolver struct {
+	Dir string
+}
+
+// NewResolver returns a Resolver rooted at dir, falling back to $AOC_INPUTS
+// and then to DefaultDir when dir is empty.
+func NewResolver(dir string) Resolver {
+	if dir == "" {
+		dir = os.Getenv(DirEnv)
+	}
+
+	if dir == "" {
+		dir = DefaultDir
+	}
+
+	return Resolver{Dir: dir}
+}
+
+// ResolveName returns name, falling back to $AOC_INPUT_NAME and then to
+// DefaultName when name is empty.
+func ResolveName(name string) string {
+	if name == "" {
+		name = os.Getenv(NameEnv)
+	}
+
+	if name == "" {
+		name = DefaultName
+	}
+
+	return name
+}
+
+// DayDir returns the directory holding the inputs of a day.
+func (r Resolver) DayDir(day int) string {
+	return filepath.Join(r.Dir, fmt.Sprintf("day%02d", day))
+}
+
+// Path returns the file of a day's named input.
+func (r Resolver) Path(day int, name string) string {
+	return filepath.Join(r.DayDir(day), name+".txt")
+}
+
+// ReadLines returns the lines of a day's named input.
+func (r Resolver) ReadLines(day int, name string) ([]string, error) {
+	return ReadLines(r.Path(day, name))
+}
+
+// Names returns the names of every input stored for a day, sorted.
+func (r Resolver) Names(day int) ([]string, error) {
+	entries, err := os.ReadDir(r.DayDir(day))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
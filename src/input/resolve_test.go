@@ -0,0 +1,72 @@
+package input_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+func TestResolverFallbacks(t *testing.T) {
+	t.Setenv(input.DirEnv, "")
+	t.Setenv(input.NameEnv, "")
+
+	if dir := input.NewResolver("").Dir; dir != input.DefaultDir {
+		t.Errorf("NewResolver(\"\").Dir = %q without $%s, want %q", dir, input.DirEnv, input.DefaultDir)
+	}
+
+	if name := input.ResolveName(""); name != input.DefaultName {
+		t.Errorf("ResolveName(\"\") = %q without $%s, want %q", name, input.NameEnv, input.DefaultName)
+	}
+
+	t.Setenv(input.DirEnv, "from-env")
+	t.Setenv(input.NameEnv, "example")
+
+	if dir := input.NewResolver("").Dir; dir != "from-env" {
+		t.Errorf("NewResolver(\"\").Dir = %q, want $%s", dir, input.DirEnv)
+	}
+
+	if dir := input.NewResolver("flag").Dir; dir != "flag" {
+		t.Errorf("NewResolver(\"flag\").Dir = %q, want the argument over $%s", dir, input.DirEnv)
+	}
+
+	if name := input.ResolveName(""); name != "example" {
+		t.Errorf("ResolveName(\"\") = %q, want $%s", name, input.NameEnv)
+	}
+
+	if name := input.ResolveName("real"); name != "real" {
+		t.Errorf("ResolveName(\"real\") = %q, want the argument over $%s", name, input.NameEnv)
+	}
+}
+
+func TestResolverFiles(t *testing.T) {
+	resolver := input.NewResolver(t.TempDir())
+
+	if want := filepath.Join(resolver.Dir, "day07", "example.txt"); resolver.Path(7, "example") != want {
+		t.Errorf("Path(7, \"example\") = %q, want %q", resolver.Path(7, "example"), want)
+	}
+
+	if err := os.MkdirAll(filepath.Join(resolver.DayDir(7), "notes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{"input.txt", "example.txt", "README.md"} {
+		if err := os.WriteFile(filepath.Join(resolver.DayDir(7), file), []byte("32T3K 765\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names, err := resolver.Names(7)
+
+	if err != nil || !reflect.DeepEqual(names, []string{"example", "input"}) {
+		t.Errorf("Names(7) = %v, %v, want [example input]", names, err)
+	}
+
+	lines, err := resolver.ReadLines(7, "input")
+
+	if err != nil || !reflect.DeepEqual(lines, []string{"32T3K 765"}) {
+		t.Errorf("ReadLines(7, \"input\") = %q, %v", lines, err)
+	}
+}
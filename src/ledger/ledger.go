@@ -0,0 +1,173 @@
+package ledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+	"github.com/gabrielgry/advent-of-code-2023/src/input"
+)
+
+// FileName is the name of the ledger kept in the inputs directory, next to
+// the inputs its answers belong to.
+const FileName = "answers.jsonl"
+
+// Attempt is one answer submitted for a named input.
+type Attempt struct {
+	Time    time.Time      `json:"time"`
+	Day     int            `json:"day"`
+	Part    int            `json:"part"`
+	Input   string         `json:"input"`
+	Answer  string         `json:"answer"`
+	Verdict client.Verdict `json:"verdict"`
+	Message string         `json:"message,omitempty"`
+}
+
+// Ledger is an append-only file of attempts, one JSON object per line.
+type Ledger struct {
+	Path string
+}
+
+// ForInputs returns the ledger kept in the directory of resolver.
+func ForInputs(resolver input.Resolver) Ledger {
+	return Ledger{Path: filepath.Join(resolver.Dir, FileName)}
+}
+
+// Record appends an attempt to the ledger.
+func (l Ledger) Record(attempt Attempt) error {
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(attempt)
+
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Attempts returns every recorded attempt in the order they were made. A
+// missing ledger has no attempts.
+func (l Ledger) Attempts() ([]Attempt, error) {
+	file, err := os.Open(l.Path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var attempts []Attempt
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber = lineNumber + 1
+
+		var attempt Attempt
+
+		if err := json.Unmarshal(scanner.Bytes(), &attempt); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", l.Path, lineNumber, err)
+		}
+
+		attempts = append(attempts, attempt)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// History holds the attempts made for one part of a day on one input.
+type History []Attempt
+
+// History returns the attempts made for one part of a day on one input.
+func (l Ledger) History(day int, part int, inputName string) (History, error) {
+	attempts, err := l.Attempts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var history History
+
+	for _, attempt := range attempts {
+		if attempt.Day == day && attempt.Part == part && attempt.Input == inputName {
+			history = append(history, attempt)
+		}
+	}
+
+	return history, nil
+}
+
+// Accepted returns the answer the website accepted, if any.
+func (h History) Accepted() (string, bool) {
+	for _, attempt := range h {
+		if attempt.Verdict == client.Correct {
+			return attempt.Answer, true
+		}
+	}
+
+	return "", false
+}
+
+// Check explains why answer is already known to be wrong, either because it
+// was rejected before or because it falls outside the bounds set by earlier
+// too high and too low verdicts. It returns an empty string when answer is
+// worth submitting.
+func (h History) Check(answer string) string {
+	value, valueErr := strconv.ParseInt(answer, 10, 64)
+
+	for _, attempt := range h {
+		if attempt.Answer == answer && attempt.Verdict.Wrong() {
+			return fmt.Sprintf("%s was already rejected as %s", answer, attempt.Verdict)
+		}
+
+		if valueErr != nil {
+			continue
+		}
+
+		bound, err := strconv.ParseInt(attempt.Answer, 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		if attempt.Verdict == client.TooHigh && value >= bound {
+			return fmt.Sprintf("%s is not below %d, which was too high", answer, bound)
+		}
+
+		if attempt.Verdict == client.TooLow && value <= bound {
+			return fmt.Sprintf("%s is not above %d, which was too low", answer, bound)
+		}
+	}
+
+	return ""
+}
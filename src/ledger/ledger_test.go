@@ -0,0 +1,59 @@
+package ledger_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gabrielgry/advent-of-code-2023/src/client"
+	"github.com/gabrielgry/advent-of-code-2023/src/ledger"
+)
+
+func TestLedger(t *testing.T) {
+	answers := ledger.Ledger{Path: filepath.Join(t.TempDir(), ledger.FileName)}
+
+	attempts := []ledger.Attempt{
+		{Day: 7, Part: 1, Input: "input", Answer: "900", Verdict: client.TooHigh},
+		{Day: 7, Part: 1, Input: "input", Answer: "100", Verdict: client.TooLow},
+		{Day: 7, Part: 1, Input: "input", Answer: "500", Verdict: client.Incorrect},
+		{Day: 7, Part: 1, Input: "alice", Answer: "640", Verdict: client.Correct},
+	}
+
+	for _, attempt := range attempts {
+		attempt.Time = time.Now()
+
+		if err := answers.Record(attempt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := answers.History(7, 1, "input")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(history) != 3 {
+		t.Fatalf("History returned %d attempts, want 3", len(history))
+	}
+
+	if accepted, ok := history.Accepted(); ok {
+		t.Errorf("Accepted() = %s, want none", accepted)
+	}
+
+	for answer, rejected := range map[string]bool{"900": true, "950": true, "100": true, "50": true, "500": true, "640": false, "abc": false} {
+		if reason := history.Check(answer); (reason != "") != rejected {
+			t.Errorf("Check(%s) = %q, want rejected %v", answer, reason, rejected)
+		}
+	}
+
+	history, err = answers.History(7, 1, "alice")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if accepted, ok := history.Accepted(); !ok || accepted != "640" {
+		t.Errorf("Accepted() = %s, %v, want 640", accepted, ok)
+	}
+}
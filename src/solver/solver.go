@@ -0,0 +1,133 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Answer is the result of one part of a puzzle.
+type Answer interface {
+	String() string
+}
+
+// Int is the Answer of puzzles solved with a plain integer.
+type Int int
+
+func (i Int) String() string {
+	return strconv.Itoa(int(i))
+}
+
+// Solver solves both parts of a day's puzzle. Parse is called exactly once
+// before any of the parts.
+type Solver interface {
+	Parse(lines []string) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+// Streamer is implemented by solvers that can solve parts whose lines are
+// independent of each other straight from a reader, one line at a time and
+// without calling Parse. Parts that cannot be streamed return
+// ErrNotImplemented.
+type Streamer interface {
+	Stream(part int, r io.Reader, maxLineLength int) (Answer, error)
+}
+
+// Explainer is implemented by solvers that can trace how they reach the
+// answer of a part, to find out why an answer is wrong. It is called after
+// Parse. Parts without a trace return ErrNotImplemented.
+type Explainer interface {
+	Explain(part int) (Trace, error)
+}
+
+// Trace records how a solver reached an answer. It is written for people with
+// WriteText and for tools with encoding/json.
+type Trace interface {
+	WriteText(w io.Writer) error
+}
+
+// Querier is implemented by solvers that answer ad-hoc questions about a
+// parsed input, written in a syntax of their own.
+type Querier interface {
+	Query(query string) (Answer, error)
+}
+
+// Renderer is implemented by solvers that can draw a parsed input back out
+// with what they found highlighted, in formats such as "ansi" or "html".
+type Renderer interface {
+	Render(w io.Writer, format string) error
+}
+
+// Factory creates a new, unparsed Solver.
+type Factory func() Solver
+
+// ErrNotImplemented is returned by the parts a day does not solve yet.
+var ErrNotImplemented = errors.New("not implemented")
+
+var (
+	mutex     sync.RWMutex
+	factories = make(map[int]Factory)
+)
+
+// Register makes a day's solver available to New. It is meant to be called
+// from the init function of the day's package and panics if the day is
+// registered twice.
+func Register(day int, factory Factory) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if factory == nil {
+		panic(fmt.Sprintf("solver: nil factory for day %d", day))
+	}
+
+	if _, ok := factories[day]; ok {
+		panic(fmt.Sprintf("solver: day %d registered twice", day))
+	}
+
+	factories[day] = factory
+}
+
+// New creates the registered solver of a day.
+func New(day int) (Solver, error) {
+	mutex.RLock()
+	factory, ok := factories[day]
+	mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no solver for day %d", day)
+	}
+
+	return factory(), nil
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	days := make([]int, 0, len(factories))
+
+	for day := range factories {
+		days = append(days, day)
+	}
+
+	sort.Ints(days)
+
+	return days
+}
+
+// Solve runs one part of an already parsed solver.
+func Solve(s Solver, part int) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1()
+	case 2:
+		return s.Part2()
+	}
+
+	return nil, fmt.Errorf("invalid part %d", part)
+}
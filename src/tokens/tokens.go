@@ -0,0 +1,196 @@
+// Package tokens finds every occurrence of a vocabulary of words in a text in
+// a single pass, overlapping ones included, using an Aho-Corasick automaton.
+package tokens
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Vocabulary maps every word to the value it stands for.
+type Vocabulary map[string]int
+
+var (
+	// Digits are the decimal digits.
+	Digits = Vocabulary{"0": 0, "1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9}
+
+	// English are the digits one to nine spelled in English.
+	English = Vocabulary{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+
+	// Zero is zero spelled in English, left out of English like the puzzle
+	// does.
+	Zero = Vocabulary{"zero": 0}
+
+	// Portuguese are the digits zero to nine spelled in Portuguese.
+	Portuguese = Vocabulary{
+		"zero": 0, "um": 1, "dois": 2, "três": 3, "quatro": 4, "cinco": 5,
+		"seis": 6, "sete": 7, "oito": 8, "nove": 9,
+	}
+
+	// Roman are the roman numerals one to nine.
+	Roman = Vocabulary{
+		"I": 1, "II": 2, "III": 3, "IV": 4, "V": 5,
+		"VI": 6, "VII": 7, "VIII": 8, "IX": 9,
+	}
+)
+
+// Merge returns a vocabulary holding the words of every vocabulary. Later
+// vocabularies win when a word is in more than one.
+func Merge(vocabularies ...Vocabulary) Vocabulary {
+	merged := make(Vocabulary)
+
+	for _, vocabulary := range vocabularies {
+		for word, value := range vocabulary {
+			merged[word] = value
+		}
+	}
+
+	return merged
+}
+
+// Match is an occurrence of a word in a text. Start and End are byte offsets,
+// End exclusive.
+type Match struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+	Value int    `json:"value"`
+}
+
+func (m Match) String() string {
+	return m.Text + "@" + strconv.Itoa(m.Start)
+}
+
+type node struct {
+	next map[byte]int
+	fail int
+	// words are the indexes of the words ending at this node, the words
+	// ending at its failure nodes included.
+	words []int
+}
+
+type word struct {
+	text  string
+	value int
+}
+
+// Scanner finds the words of a vocabulary in texts. It is safe for
+// concurrent use.
+type Scanner struct {
+	nodes []node
+	words []word
+}
+
+// NewScanner builds a Scanner for the words of every vocabulary. Empty words
+// are ignored.
+func NewScanner(vocabularies ...Vocabulary) *Scanner {
+	merged := Merge(vocabularies...)
+
+	texts := make([]string, 0, len(merged))
+
+	for text := range merged {
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+
+	sort.Strings(texts)
+
+	scanner := &Scanner{nodes: []node{{next: make(map[byte]int)}}}
+
+	for _, text := range texts {
+		scanner.insert(word{text: text, value: merged[text]})
+	}
+
+	scanner.link()
+
+	return scanner
+}
+
+func (s *Scanner) insert(w word) {
+	current := 0
+
+	for i := 0; i < len(w.text); i = i + 1 {
+		next, ok := s.nodes[current].next[w.text[i]]
+
+		if !ok {
+			next = len(s.nodes)
+			s.nodes = append(s.nodes, node{next: make(map[byte]int)})
+			s.nodes[current].next[w.text[i]] = next
+		}
+
+		current = next
+	}
+
+	s.nodes[current].words = append(s.nodes[current].words, len(s.words))
+	s.words = append(s.words, w)
+}
+
+// link sets the failure link of every node to the node of its longest proper
+// suffix in the trie, visiting the nodes breadth first so that shallower
+// links are always ready.
+func (s *Scanner) link() {
+	var queue []int
+
+	for _, child := range s.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for char, child := range s.nodes[current].next {
+			fail := s.step(s.nodes[current].fail, char)
+
+			s.nodes[child].fail = fail
+			s.nodes[child].words = append(s.nodes[child].words, s.nodes[fail].words...)
+
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (s *Scanner) step(state int, char byte) int {
+	for {
+		if next, ok := s.nodes[state].next[char]; ok {
+			return next
+		}
+
+		if state == 0 {
+			return 0
+		}
+
+		state = s.nodes[state].fail
+	}
+}
+
+// FindAll returns every match in text, overlapping ones included, ordered by
+// start and then by end.
+func (s *Scanner) FindAll(text string) []Match {
+	var matches []Match
+
+	state := 0
+
+	for i := 0; i < len(text); i = i + 1 {
+		state = s.step(state, text[i])
+
+		for _, index := range s.nodes[state].words {
+			w := s.words[index]
+			matches = append(matches, Match{Start: i + 1 - len(w.text), End: i + 1, Text: w.text, Value: w.value})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+
+		return matches[i].End < matches[j].End
+	})
+
+	return matches
+}
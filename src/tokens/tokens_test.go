@@ -0,0 +1,47 @@
+package tokens
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		vocabularies []Vocabulary
+		text         string
+		want         string
+	}{
+		{[]Vocabulary{Digits, English}, "twone", "[two@0 one@2]"},
+		{[]Vocabulary{Digits, English}, "eightwothree", "[eight@0 two@4 three@7]"},
+		{[]Vocabulary{Digits, English}, "7pqrstsixteen", "[7@0 six@6]"},
+		{[]Vocabulary{Digits, English}, "oneoneight1", "[one@0 one@3 eight@5 1@10]"},
+		{[]Vocabulary{Digits, English}, "abc", "[]"},
+		{[]Vocabulary{English, Zero}, "zerone", "[zero@0 one@3]"},
+		{[]Vocabulary{Portuguese}, "doisetetrês", "[dois@0 sete@3 três@7]"},
+		{[]Vocabulary{Roman}, "xVIIIx", "[V@1 VI@1 VII@1 VIII@1 I@2 II@2 III@2 I@3 II@3 I@4]"},
+	}
+
+	for _, test := range tests {
+		got := fmt.Sprint(NewScanner(test.vocabularies...).FindAll(test.text))
+
+		if got != test.want {
+			t.Errorf("FindAll(%q) = %s, want %s", test.text, got, test.want)
+		}
+	}
+}
+
+func TestFindAllValues(t *testing.T) {
+	matches := NewScanner(Digits, English).FindAll("3nine")
+
+	if len(matches) != 2 || matches[0].Value != 3 || matches[1].Value != 9 || matches[1].End != 5 {
+		t.Errorf("FindAll(%q) = %+v", "3nine", matches)
+	}
+}
+
+func TestMergeOverrides(t *testing.T) {
+	merged := Merge(English, Vocabulary{"one": 100})
+
+	if merged["one"] != 100 || merged["two"] != 2 {
+		t.Errorf("Merge = %v", merged)
+	}
+}